@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/JohannesKaufmann/dom"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
@@ -40,6 +42,18 @@ func (cli *CLI) includeNodesFromDoc(doc *html.Node) (*html.Node, error) {
 	nodes := cascadia.QueryAll(doc, cli.config.includeSelector)
 
 	root := &html.Node{}
+
+	// The <base href> is needed to resolve the relative links,
+	// even if the <head> is not part of the selection.
+	baseNode := dom.FindFirstNode(doc, func(n *html.Node) bool {
+		_, hasHref := dom.GetAttribute(n, "href")
+		return dom.NodeName(n) == "base" && hasHref
+	})
+	if baseNode != nil && !slices.Contains(nodes, baseNode) {
+		dom.RemoveNode(baseNode)
+		root.AppendChild(baseNode)
+	}
+
 	for _, n := range nodes {
 		dom.RemoveNode(n)
 		root.AppendChild(n)
@@ -91,7 +105,7 @@ func (cli *CLI) parseInputWithSelectors(input []byte) (*html.Node, error) {
 	return doc, nil
 }
 
-func (cli *CLI) convert(input []byte) ([]byte, error) {
+func (cli *CLI) newConverter() *converter.Converter {
 	conv := converter.NewConverter(
 		converter.WithPlugins(
 			base.NewBasePlugin(),
@@ -100,6 +114,10 @@ func (cli *CLI) convert(input []byte) ([]byte, error) {
 			),
 		),
 	)
+	for _, tagName := range cli.config.tagTypeKeep {
+		conv.Register.TagType(tagName, converter.TagTypeKeep, converter.PriorityEarly)
+	}
+
 	if cli.config.enablePluginStrikethrough {
 		conv.Register.Plugin(strikethrough.NewStrikethroughPlugin())
 	}
@@ -112,17 +130,47 @@ func (cli *CLI) convert(input []byte) ([]byte, error) {
 				table.WithSpanCellBehavior(table.SpanCellBehavior(cli.config.tableSpanCellBehavior)),
 				table.WithPresentationTables(cli.config.tablePresentationTables),
 				table.WithNewlineBehavior(table.NewlineBehavior(cli.config.tableNewlineBehavior)),
+				table.WithFallbackBehavior(table.FallbackBehavior(cli.config.tableFallbackBehavior)),
 				table.WithCellPaddingBehavior(table.CellPaddingBehavior(cli.config.tableCellPaddingBehavior)),
+				table.WithTableFormat(table.TableFormat(cli.config.tableFormat)),
+				table.WithMaxColumnWidth(cli.config.tableMaxColumnWidth),
+				table.WithMaxTableWidth(cli.config.tableMaxTableWidth),
+				table.WithOverflowBehavior(table.OverflowBehavior(cli.config.tableOverflowBehavior)),
+				table.WithRenderMode(table.RenderMode(cli.config.tableRenderMode)),
+				table.WithLayoutTableThreshold(cli.config.tableLayoutThreshold),
+				table.WithLayoutTableDebug(cli.config.tableLayoutDebug),
+				table.WithCaptionPosition(table.CaptionPosition(cli.config.tableCaptionPosition)),
+				table.WithCaptionStyle(table.CaptionStyle(cli.config.tableCaptionStyle)),
+				table.WithCaptionFallback(cli.config.tableCaptionFallback),
+				table.WithHeaderRowMerging(cli.config.tableMergeHeaderRows),
+				table.WithSectionRowBehavior(table.SectionRowBehavior(cli.config.tableSectionRowBehavior)),
+				table.WithNumericAlignment(cli.config.tableNumericAlignment),
+				table.WithTableHandler(collectTable),
 			),
 		)
 	}
 
+	return conv
+}
+
+func (cli *CLI) convert(ctx context.Context, input []byte, opts ...converter.ConvertOptionFunc) ([]byte, error) {
+	return cli.convertWith(ctx, cli.newConverter(), input, opts...)
+}
+
+// convertWith is like convert but uses the provided converter,
+// which can have additional handlers registered.
+func (cli *CLI) convertWith(ctx context.Context, conv *converter.Converter, input []byte, opts ...converter.ConvertOptionFunc) ([]byte, error) {
 	doc, err := cli.parseInputWithSelectors(input)
 	if err != nil {
 		return nil, err
 	}
 
-	markdown, err := conv.ConvertNode(doc, converter.WithDomain(cli.config.domain))
+	opts = append([]converter.ConvertOptionFunc{
+		converter.WithContext(ctx),
+		converter.WithDomain(cli.config.domain),
+	}, opts...)
+
+	markdown, err := conv.ConvertNode(doc, opts...)
 	if err != nil {
 
 		var validationErr *commonmark.ValidateConfigError
@@ -50,6 +50,29 @@ Use a HTML sanitizer before displaying the HTML in the browser!
     html2markdown --input "src/*.html" --output "dist/"
 
 
+## Server
+
+Instead of starting a new process for every document, you can also run
+the converter as a local http service:
+
+    html2markdown serve --addr :8080
+
+    curl --data-binary @file.html "http://localhost:8080/convert?plugin-table&domain=example.com"
+
+The html is sent as the raw body (or as the "file" field of a multipart form)
+to "POST /convert". The query parameters are the same as the flags below.
+There is also "GET /healthz" and "GET /metrics".
+
+    --addr ADDRESS
+        The address the server listens on (default ":8080")
+
+    --max-body-size BYTES
+        The maximum size of the request body (default 10485760)
+
+    --timeout DURATION
+        The maximum duration of one conversion (default "30s")
+
+
 ## Flags
 
     -v, --version
@@ -66,6 +89,9 @@ Use a HTML sanitizer before displaying the HTML in the browser!
     --output-overwrite
         Replace existing files
 
+    --link-mapping
+        Rewrite the links between the converted files to point to the ".md" files
+
     If --input is a directory or glob pattern, --output must be a directory.
 
 
@@ -101,7 +127,7 @@ func tmpl(w io.Writer, text string, data interface{}) error {
 func (cli *CLI) initUsageText() error {
 	var flags []*flag.Flag
 	cli.flags.VisitAll(func(f *flag.Flag) {
-		if f.Name == "v" || f.Name == "version" || f.Name == "input" || f.Name == "output" || f.Name == "output-overwrite" {
+		if f.Name == "v" || f.Name == "version" || f.Name == "input" || f.Name == "output" || f.Name == "output-overwrite" || f.Name == "link-mapping" {
 			// We manually mention these in the usage
 			return
 		}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type serveConfig struct {
+	addr        string
+	maxBodySize int64
+	timeout     time.Duration
+}
+
+// These flags only make sense for files (or fetching) and are therefore
+// not accepted as query parameters.
+var disallowedQueryParams = []string{
+	"v", "version",
+	"input", "output", "output-overwrite", "link-mapping",
+	"url", "header-file", "cookie-file",
+	"download-images",
+	"tables-dir", "tables-format",
+}
+
+func (cli *CLI) initServeFlags(cfg *serveConfig) *flag.FlagSet {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	flags.StringVar(&cfg.addr, "addr", ":8080", "the address the server listens on")
+	flags.Int64Var(&cfg.maxBodySize, "max-body-size", 10<<20, "the maximum size of the request body in bytes")
+	flags.DurationVar(&cfg.timeout, "timeout", 30*time.Second, "the maximum duration of one conversion")
+
+	return flags
+}
+
+func (cli *CLI) runServe(args []string) error {
+	var cfg serveConfig
+	flags := cli.initServeFlags(&cfg)
+
+	err := flags.Parse(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return NewCLIError(
+			fmt.Errorf("invalid flag for serve: %w", err),
+			Paragraph("Here is how you can start the server:"),
+			CodeBlock(`html2markdown serve --addr :8080`),
+		)
+	}
+	if flags.NArg() != 0 {
+		return NewCLIError(
+			fmt.Errorf("unknown arguments: %s", strings.Join(flags.Args(), " ")),
+			Paragraph("Here is how you can start the server:"),
+			CodeBlock(`html2markdown serve --addr :8080`),
+		)
+	}
+	if cfg.maxBodySize <= 0 {
+		return fmt.Errorf("--max-body-size must be greater than zero")
+	}
+	if cfg.timeout <= 0 {
+		return fmt.Errorf("--timeout must be greater than zero")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:              cfg.addr,
+		Handler:           newServer(cfg).handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.ListenAndServe()
+	}()
+	fmt.Fprintf(cli.Stderr, "listening on %s\n", cfg.addr)
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		// Give the running conversions some time to finish...
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+		defer cancel()
+
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - //
+
+type server struct {
+	config  serveConfig
+	metrics *serverMetrics
+}
+
+func newServer(cfg serveConfig) *server {
+	return &server{
+		config:  cfg,
+		metrics: newServerMetrics(),
+	}
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /convert", s.handleConvert)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+
+	return mux
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	s.metrics.write(w)
+}
+
+func (s *server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	status, body := s.convert(w, r)
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+
+	s.metrics.observe(status, time.Since(start), len(body))
+}
+
+func errorBody(err error) []byte {
+	return []byte(err.Error() + "\n")
+}
+
+func (s *server) convert(w http.ResponseWriter, r *http.Request) (int, []byte) {
+	reqCLI, err := cliFromQuery(r.URL.Query())
+	if err != nil {
+		return http.StatusBadRequest, errorBody(err)
+	}
+
+	// With the ResponseWriter the server closes the
+	// connection once the limit is reached.
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.maxBodySize)
+	input, err := readRequestInput(r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return http.StatusRequestEntityTooLarge, errorBody(
+				fmt.Errorf("the request body is larger than %d bytes", maxBytesErr.Limit),
+			)
+		}
+		return http.StatusBadRequest, errorBody(err)
+	}
+	s.metrics.addInputBytes(len(input))
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.timeout)
+	defer cancel()
+
+	markdown, err := reqCLI.convert(ctx, input)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return http.StatusGatewayTimeout, errorBody(
+				fmt.Errorf("the conversion took longer than %s", s.config.timeout),
+			)
+		}
+		if errors.Is(err, context.Canceled) {
+			// The client closed the connection before the conversion finished.
+			return http.StatusRequestTimeout, errorBody(err)
+		}
+		return http.StatusBadRequest, errorBody(err)
+	}
+
+	return http.StatusOK, markdown
+}
+
+// cliFromQuery maps the query parameters to the flags of the cli,
+// so that "?plugin-table&domain=example.com" works the same as
+// "--plugin-table --domain=example.com" would.
+func cliFromQuery(query map[string][]string) (*CLI, error) {
+	reqCLI := &CLI{}
+	reqCLI.initFlags(projectBinary)
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, key := range keys {
+		if slices.Contains(disallowedQueryParams, key) {
+			return nil, fmt.Errorf("the query parameter %q is not supported by the server", key)
+		}
+
+		f := reqCLI.flags.Lookup(key)
+		if f == nil {
+			return nil, fmt.Errorf("unknown query parameter %q", key)
+		}
+		boolFlag, isBool := f.Value.(interface{ IsBoolFlag() bool })
+
+		for _, val := range query[key] {
+			if isBool && boolFlag.IsBoolFlag() && val == "" {
+				// "?plugin-table" is the same as "?plugin-table=true"
+				args = append(args, "--"+key)
+				continue
+			}
+			args = append(args, "--"+key+"="+val)
+		}
+	}
+
+	err := reqCLI.parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return reqCLI, nil
+}
+
+// readRequestInput reads the html either from the raw body or
+// from a multipart form (the "file" field or the "html" field).
+func readRequestInput(r *http.Request) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errors.New(`the multipart form needs a "file" or "html" field`)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := part.FormName()
+		if name == "file" || name == "html" {
+			defer part.Close()
+			return io.ReadAll(part)
+		}
+		part.Close()
+	}
+}
+
+// - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - //
+
+type serverMetrics struct {
+	m sync.Mutex
+
+	requests      map[int]int64
+	inputBytes    int64
+	outputBytes   int64
+	durationSum   float64
+	durationCount int64
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		requests: make(map[int]int64),
+	}
+}
+
+func (m *serverMetrics) addInputBytes(n int) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.inputBytes += int64(n)
+}
+func (m *serverMetrics) observe(status int, duration time.Duration, outputBytes int) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.requests[status]++
+	m.durationSum += duration.Seconds()
+	m.durationCount++
+	if status == http.StatusOK {
+		m.outputBytes += int64(outputBytes)
+	}
+}
+
+// write outputs the metrics in the prometheus text format.
+func (m *serverMetrics) write(w io.Writer) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	codes := make([]int, 0, len(m.requests))
+	for code := range m.requests {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	fmt.Fprintln(w, "# HELP html2markdown_convert_requests_total The number of conversion requests by status code.")
+	fmt.Fprintln(w, "# TYPE html2markdown_convert_requests_total counter")
+	for _, code := range codes {
+		fmt.Fprintf(w, "html2markdown_convert_requests_total{code=\"%d\"} %d\n", code, m.requests[code])
+	}
+
+	fmt.Fprintln(w, "# HELP html2markdown_convert_duration_seconds The duration of the conversion requests.")
+	fmt.Fprintln(w, "# TYPE html2markdown_convert_duration_seconds summary")
+	fmt.Fprintf(w, "html2markdown_convert_duration_seconds_sum %g\n", m.durationSum)
+	fmt.Fprintf(w, "html2markdown_convert_duration_seconds_count %d\n", m.durationCount)
+
+	fmt.Fprintln(w, "# HELP html2markdown_input_bytes_total The number of html bytes received.")
+	fmt.Fprintln(w, "# TYPE html2markdown_input_bytes_total counter")
+	fmt.Fprintf(w, "html2markdown_input_bytes_total %d\n", m.inputBytes)
+
+	fmt.Fprintln(w, "# HELP html2markdown_output_bytes_total The number of markdown bytes sent.")
+	fmt.Fprintln(w, "# TYPE html2markdown_output_bytes_total counter")
+	fmt.Fprintf(w, "html2markdown_output_bytes_total %d\n", m.outputBytes)
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(newServer(serveConfig{
+		maxBodySize: 1024,
+		timeout:     5 * time.Second,
+	}).handler())
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func doRequest(t *testing.T, req *http.Request) (int, string) {
+	t.Helper()
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, string(body)
+}
+
+func TestServe_Convert(t *testing.T) {
+	srv := newTestServer(t)
+
+	testCases := []struct {
+		desc  string
+		query string
+		input string
+
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			desc:  "basic",
+			input: `<p>Some <strong>bold</strong> text</p>`,
+
+			expectedStatus: http.StatusOK,
+			expectedBody:   "Some **bold** text",
+		},
+		{
+			desc:  "with domain",
+			query: "?domain=https://example.com",
+			input: `<img src="/image.png" />`,
+
+			expectedStatus: http.StatusOK,
+			expectedBody:   "![](https://example.com/image.png)",
+		},
+		{
+			desc:  "with selectors",
+			query: "?exclude-selector=.ad&exclude-selector=nav",
+			input: `<nav>menu</nav><p>Some <span class="ad">ad</span> text</p>`,
+
+			expectedStatus: http.StatusOK,
+			expectedBody:   "Some text",
+		},
+		{
+			desc:  "with plugin and option",
+			query: "?plugin-table&opt-table-cell-padding-behavior=none",
+			input: `<table><tr><th>A</th></tr><tr><td>1</td></tr></table>`,
+
+			expectedStatus: http.StatusOK,
+			expectedBody:   "|A|\n|---|\n|1|",
+		},
+		{
+			desc:  "with strong delimiter",
+			query: "?opt-strong-delimiter=__",
+			input: `<strong>bold</strong>`,
+
+			expectedStatus: http.StatusOK,
+			expectedBody:   "__bold__",
+		},
+
+		{
+			desc:  "unknown query parameter",
+			query: "?random=1",
+			input: `<strong>bold</strong>`,
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "unknown query parameter \"random\"\n",
+		},
+		{
+			desc:  "query parameter for files",
+			query: "?output=file.md",
+			input: `<strong>bold</strong>`,
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "the query parameter \"output\" is not supported by the server\n",
+		},
+		{
+			desc:  "query parameter for table files",
+			query: "?plugin-table&tables-dir=/tmp/tables&tables-format=json",
+			input: `<strong>bold</strong>`,
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "the query parameter \"tables-dir\" is not supported by the server\n",
+		},
+		{
+			desc:  "option requires plugin",
+			query: "?opt-table-skip-empty-rows",
+			input: `<strong>bold</strong>`,
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "--opt-table-skip-empty-rows requires --plugin-table to be enabled\n",
+		},
+		{
+			desc:  "invalid option value",
+			query: "?opt-strong-delimiter=1234",
+			input: `<strong>bold</strong>`,
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid value for --opt-strong-delimiter=\"1234\" must be exactly 2 characters of \"**\" or \"__\"\n",
+		},
+		{
+			desc:  "body too large",
+			input: strings.Repeat("a", 2000),
+
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   "the request body is larger than 1024 bytes\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, srv.URL+"/convert"+tC.query, strings.NewReader(tC.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			status, body := doRequest(t, req)
+			if status != tC.expectedStatus {
+				t.Errorf("expected status %d but got %d", tC.expectedStatus, status)
+			}
+			if body != tC.expectedBody {
+				t.Errorf("expected %q but got %q", tC.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestServe_Multipart(t *testing.T) {
+	srv := newTestServer(t)
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("other", "ignored")
+	fw, err := mw.CreateFormFile("file", "index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte(`<em>italic</em>`))
+	mw.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/convert", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	status, body := doRequest(t, req)
+	if status != http.StatusOK {
+		t.Errorf("expected status 200 but got %d", status)
+	}
+	if body != "*italic*" {
+		t.Errorf("expected different body but got %q", body)
+	}
+}
+
+func TestServe_Timeout(t *testing.T) {
+	srv := httptest.NewServer(newServer(serveConfig{
+		maxBodySize: 1 << 20,
+		timeout:     time.Nanosecond,
+	}).handler())
+	defer srv.Close()
+
+	input := strings.Repeat("<p>Some <strong>bold</strong> text</p>", 1000)
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/convert", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := doRequest(t, req)
+	if status != http.StatusGatewayTimeout {
+		t.Errorf("expected status 504 but got %d", status)
+	}
+	if body != "the conversion took longer than 1ns\n" {
+		t.Errorf("expected different body but got %q", body)
+	}
+}
+
+func TestServe_HealthAndMetrics(t *testing.T) {
+	srv := newTestServer(t)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/healthz", nil)
+	status, body := doRequest(t, req)
+	if status != http.StatusOK || body != "ok\n" {
+		t.Errorf("expected healthy server but got %d %q", status, body)
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/convert", strings.NewReader("<strong>bold</strong>"))
+	doRequest(t, req)
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/convert?random", strings.NewReader("<strong>bold</strong>"))
+	doRequest(t, req)
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/metrics", nil)
+	status, body = doRequest(t, req)
+	if status != http.StatusOK {
+		t.Errorf("expected status 200 but got %d", status)
+	}
+	for _, expected := range []string{
+		`html2markdown_convert_requests_total{code="200"} 1`,
+		`html2markdown_convert_requests_total{code="400"} 1`,
+		`html2markdown_convert_duration_seconds_count 2`,
+		`html2markdown_input_bytes_total 21`,
+		`html2markdown_output_bytes_total 8`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected the metrics to contain %q but got:\n%s", expected, body)
+		}
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/convert", nil)
+	status, _ = doRequest(t, req)
+	if status != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405 but got %d", status)
+	}
+}
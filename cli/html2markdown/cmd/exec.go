@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"github.com/andybalholm/cascadia"
 )
 
@@ -24,8 +26,18 @@ type Config struct {
 	args []string
 
 	inputFilepath   string
+	inputURL        string
 	outputFilepath  string
 	outputOverwrite bool
+	linkMapping     bool
+
+	downloadImagesDir string
+
+	tablesDir    string
+	tablesFormat string
+
+	headerFilepath string
+	cookieFilepath string
 
 	// - - - - - General - - - - - //
 	version bool
@@ -34,6 +46,8 @@ type Config struct {
 	includeSelector cascadia.SelectorGroup
 	excludeSelector cascadia.SelectorGroup
 
+	tagTypeKeep []string
+
 	// - - - - - Options - - - - - //
 	strongDelimiter string
 
@@ -46,7 +60,21 @@ type Config struct {
 	tableSpanCellBehavior    string
 	tablePresentationTables  bool
 	tableNewlineBehavior     string
+	tableFallbackBehavior    string
 	tableCellPaddingBehavior string
+	tableFormat              string
+	tableMaxColumnWidth      int
+	tableMaxTableWidth       int
+	tableOverflowBehavior    string
+	tableRenderMode          string
+	tableLayoutThreshold     int
+	tableLayoutDebug         bool
+	tableCaptionPosition     string
+	tableCaptionStyle        string
+	tableCaptionFallback     bool
+	tableMergeHeaderRows     bool
+	tableSectionRowBehavior  string
+	tableNumericAlignment    bool
 }
 
 // Release holds the information (from the 3 ldflags) that goreleaser sets.
@@ -128,6 +156,9 @@ func (cli *CLI) Execute() {
 }
 
 func (cli *CLI) run() ([]error, error) {
+	if len(cli.OsArgs) > 1 && cli.OsArgs[1] == "serve" {
+		return nil, cli.runServe(cli.OsArgs[2:])
+	}
 
 	err := cli.parseFlags(cli.OsArgs[1:])
 	if err != nil {
@@ -169,22 +200,86 @@ func (cli *CLI) run() ([]error, error) {
 		return nil, err
 	}
 
-	for _, input := range inputs {
-		data, err := cli.readInput(input)
+	var mapper *linkMapper
+	if cli.config.linkMapping {
+		if outputType != outputTypeDirectory {
+			return nil, NewCLIError(
+				fmt.Errorf("--link-mapping requires --output to be a directory"),
+				Paragraph("Here is how you can convert a whole website:"),
+				CodeBlock(`html2markdown --input "site/**/*.html" --output "docs/" --link-mapping`),
+			)
+		}
+		mapper = newLinkMapper(cli.config.inputFilepath, inputs)
+	}
+
+	var assetsPath string
+	if cli.config.downloadImagesDir != "" {
+		assetsPath, err = cli.assetsPath(outputType)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		markdown, err := cli.convert(data)
+	if cli.config.tablesDir != "" {
+		err = os.MkdirAll(cli.config.tablesDir, os.ModePerm)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	var warnings []error
+	addWarning := func(err error) {
+		warnings = append(warnings, err)
+	}
+
+	for _, input := range inputs {
+		data, err := cli.readInput(input)
+		if err != nil {
+			return warnings, err
+		}
+
+		opts := []converter.ConvertOptionFunc{
+			converter.WithWarningHandler(addWarning),
+		}
+		if cli.config.domain == "" && input.domain != "" {
+			opts = append(opts, converter.WithDomain(input.domain))
+		}
+
+		conv := cli.newConverter()
+		if mapper != nil {
+			conv.Register.URLRewriter(mapper.urlRewriter(input, addWarning), converter.PriorityStandard)
+		}
+		if cli.config.downloadImagesDir != "" {
+			plugin, err := cli.newImageAssetsPlugin(input, assetsPath)
+			if err != nil {
+				return warnings, err
+			}
+			conv.Register.Plugin(plugin)
+		}
+
+		ctx := context.Background()
+		collector := &tableCollector{}
+		if cli.config.tablesDir != "" {
+			ctx = withTableCollector(ctx, collector)
+		}
+
+		markdown, err := cli.convertWith(ctx, conv, data, opts...)
+		if err != nil {
+			return warnings, err
+		}
 
 		err = cli.writeOutput(outputType, input.outputFullFilepath, markdown)
 		if err != nil {
-			return nil, err
+			return warnings, err
+		}
+
+		if cli.config.tablesDir != "" {
+			err = cli.writeTables(outputType, input, collector.tables)
+			if err != nil {
+				return warnings, err
+			}
 		}
 	}
 
-	return nil, nil
+	return warnings, nil
 }
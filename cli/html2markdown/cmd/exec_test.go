@@ -327,6 +327,18 @@ func TestExecute(t *testing.T) {
 				inputArgs:  []string{"html2markdown"},
 			},
 		},
+		{
+			desc: "[convert] table with warning",
+
+			input: CLIGoldenInput{
+				modeStdin:  modePipe,
+				modeStdout: modePipe,
+				modeStderr: modePipe,
+
+				inputStdin: []byte("<table><tr><td>A1</td><td>A very long text</td></tr></table>"),
+				inputArgs:  []string{"html2markdown", "--plugin-table", "--opt-table-max-column-width=8"},
+			},
+		},
 
 		// - - - - - selectors - - - - - //
 		{
@@ -392,6 +404,43 @@ func TestExecute(t *testing.T) {
 			},
 		},
 
+		{
+			desc: "[tag-type-keep] inline and block",
+
+			input: CLIGoldenInput{
+				modeStdin:  modePipe,
+				modeStdout: modePipe,
+				modeStderr: modePipe,
+
+				inputStdin: []byte(`<p>E = mc<sup onclick="alert()">2</sup> is <mark>famous</mark></p><table><tr><td>A</td></tr></table>`),
+				inputArgs:  []string{"html2markdown", "--tag-type-keep", "sup, mark", "--tag-type-keep", "table"},
+			},
+		},
+		{
+			desc: "[tag-type-keep] selector",
+
+			input: CLIGoldenInput{
+				modeStdin:  modePipe,
+				modeStdout: modePipe,
+				modeStderr: modePipe,
+
+				inputStdin: []byte(`<p>E = mc<sup>2</sup></p>`),
+				inputArgs:  []string{"html2markdown", "--tag-type-keep", "p > sup"},
+			},
+		},
+		{
+			desc: "[tag-type-keep] script",
+
+			input: CLIGoldenInput{
+				modeStdin:  modePipe,
+				modeStdout: modePipe,
+				modeStderr: modePipe,
+
+				inputStdin: []byte(`<p>hi<script>alert(1)</script></p>`),
+				inputArgs:  []string{"html2markdown", "--tag-type-keep", "script"},
+			},
+		},
+
 		// - - - - - validation of options - - - - - //
 		{
 			desc: "[validation] no value",
@@ -745,6 +794,145 @@ func TestExecute_Plugins(t *testing.T) {
 
 			expectedStdout: []byte("| A1 | A2 |\n|----|----|\n| C1 | C2 |\n"),
 		},
+		{
+			desc: "[plugin-table] fallback behavior html",
+
+			inputStdin: []byte(`
+<table>
+  <tr>
+    <td>A1</td>
+    <td><ul><li>A2</li></ul></td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-fallback-behavior=html"},
+
+			expectedStdout: []byte("<table>\n<tbody>\n<tr>\n<td>A1</td>\n<td><ul><li>A2</li></ul></td>\n</tr>\n</tbody>\n</table>\n"),
+		},
+		{
+			desc: "[plugin-table] table format grid",
+
+			inputStdin: []byte(`
+<table>
+  <tr>
+    <td>A1</td>
+    <td><ul><li>A2</li></ul></td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-format=grid"},
+
+			expectedStdout: []byte("+----+------+\n| A1 | - A2 |\n+----+------+\n"),
+		},
+		{
+			desc: "[plugin-table] render mode list",
+
+			inputStdin: []byte(`
+<table>
+  <tr>
+    <th>Name</th>
+    <th>City</th>
+  </tr>
+  <tr>
+    <td>Max</td>
+    <td>Berlin</td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-render-mode=list"},
+
+			expectedStdout: []byte("- Name: Max, City: Berlin\n"),
+		},
+		{
+			desc: "[plugin-table] layout table threshold",
+
+			inputStdin: []byte(`
+<table width="600" border="0">
+  <tr>
+    <td><h1>Newsletter</h1></td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-layout-threshold=3"},
+
+			expectedStdout: []byte("# Newsletter\n"),
+		},
+		{
+			desc: "[plugin-table] caption style",
+
+			inputStdin: []byte(`
+<table>
+  <caption>People</caption>
+  <tr>
+    <td>Max</td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-caption-position=above", "--opt-table-caption-style=pandoc"},
+
+			expectedStdout: []byte("Table: People\n\n|     |\n|-----|\n| Max |\n"),
+		},
+		{
+			desc: "[plugin-table] caption fallback",
+
+			inputStdin: []byte(`
+<table aria-label="People">
+  <tr>
+    <td>Max</td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-caption-fallback"},
+
+			expectedStdout: []byte("|     |\n|-----|\n| Max |\n\nPeople\n"),
+		},
+		{
+			desc: "[plugin-table] merge header rows and split sections",
+
+			inputStdin: []byte(`
+<table>
+  <tr>
+    <th></th>
+    <th colspan="2">Size</th>
+  </tr>
+  <tr>
+    <th>Name</th>
+    <th>Width</th>
+    <th>Height</th>
+  </tr>
+  <tr>
+    <th colspan="3">Doors</th>
+  </tr>
+  <tr>
+    <td>Front</td>
+    <td>1</td>
+    <td>2</td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-merge-header-rows", "--opt-table-section-row-behavior=split"},
+
+			expectedStdout: []byte("## Doors\n\n| Name  | Size / Width | Size / Height |\n|-------|--------------|---------------|\n| Front | 1            | 2             |\n"),
+		},
+		{
+			desc: "[plugin-table] numeric alignment",
+
+			inputStdin: []byte(`
+<table>
+  <tr>
+    <th>Name</th>
+    <th>Price</th>
+  </tr>
+  <tr>
+    <td>Tea</td>
+    <td>1.50</td>
+  </tr>
+</table>
+			`),
+			inputArgs: []string{"html2markdown", "--plugin-table", "--opt-table-numeric-alignment"},
+
+			expectedStdout: []byte("| Name | Price |\n|------|------:|\n| Tea  | 1.50  |\n"),
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
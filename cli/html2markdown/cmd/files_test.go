@@ -770,3 +770,72 @@ func TestWriteFile_OverrideTrue(t *testing.T) {
 	}
 	expectRepresentation(t, directoryPath, ".\n"+`├─test.txt "B"`) // <-- the new content
 }
+
+func TestExecute_LinkMapping(t *testing.T) {
+	directoryPath := newTestDir(t)
+	defer os.RemoveAll(directoryPath)
+
+	chdirWithCleanup(t, directoryPath)
+
+	files := map[string]string{
+		"index.html":                `<a href="guide/">Guide</a> <a href="/guide/intro.html#install">Install</a> <a href="https://example.com/guide/intro.html">External</a>`,
+		"guide/index.html":          `<a href="intro.html?lang=en">Intro</a> <a href="../index.html">Home</a> <a href="#top">Top</a>`,
+		"guide/intro.html":          `<a href="./advanced/setup.html#step-2">Setup</a> <a href="../missing.html">Missing</a> <a href="mailto:hi@example.com">Mail</a>`,
+		"guide/advanced/setup.html": `<a href="../intro.html">Back</a> <img src="../logo.png" />`,
+	}
+	for name, content := range files {
+		path := filepath.Join(directoryPath, "site", filepath.FromSlash(name))
+
+		err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// - - - - - - - - - //
+	args := []string{"html2markdown", "--input", filepath.Join("site", "**", "*.html"), "--output", "docs" + "/", "--link-mapping"}
+
+	stdin := &FakeFile{mode: modeTerminal}
+	stdout := &FakeFile{mode: modePipe}
+	stderr := &FakeFile{mode: modePipe}
+
+	Run(stdin, stdout, stderr, args, testRelease)
+
+	expectedStderr := fmt.Sprintf("\nwarning: the link %q in %q points to a file that is not part of the conversion\n\n", "../missing.html", filepath.Join("site", "guide", "intro.html"))
+	if stderr.String() != expectedStderr {
+		t.Fatalf("expected stderr %q but got %q", expectedStderr, stderr.String())
+	}
+	if len(stdout.Bytes()) != 0 {
+		t.Fatalf("expected no stdout content")
+	}
+	// - - - - - - - - - //
+
+	expectRepresentation(t, filepath.Join(directoryPath, "docs"), `
+.
+├─index.90937c4c50.md "[Intro](intro.md) [Home](index.md) [Top](#top)"
+├─index.md "[Guide](index.90937c4c50.md) [Install](intro.md#install) [External](https://example.com/guide/intro.html)"
+├─intro.md "[Setup](setup.md#step-2) [Missing](../missing.html) [Mail](mailto:hi@example.com)"
+├─setup.md "[Back](intro.md) ![](../logo.png)"
+	`)
+}
+
+func TestExecute_LinkMappingWithoutDirectory(t *testing.T) {
+	directoryPath := newTestDirWithFiles(t)
+	defer os.RemoveAll(directoryPath)
+
+	args := []string{"html2markdown", "--input", filepath.Join(directoryPath, "input", "website_a.html"), "--link-mapping"}
+
+	stdin := &FakeFile{mode: modeTerminal}
+	stdout := &FakeFile{mode: modePipe}
+	stderr := &FakeFile{mode: modePipe}
+
+	Run(stdin, stdout, stderr, args, testRelease)
+
+	if !strings.Contains(stderr.String(), "--link-mapping requires --output to be a directory") {
+		t.Fatalf("expected a different error but got %q", stderr.String())
+	}
+}
@@ -28,6 +28,29 @@ func (cli *CLI) selectorFlag(target *cascadia.SelectorGroup, name string, usage
 	})
 }
 
+// tagNamesFlag sets up a flag that parses a comma separated list of tag names (e.g. "sup,sub").
+func (cli *CLI) tagNamesFlag(target *[]string, name string, usage string) {
+	cli.flags.Func(name, usage, func(flagValue string) error {
+		for _, tagName := range strings.Split(flagValue, ",") {
+			tagName = strings.ToLower(strings.TrimSpace(tagName))
+			if tagName == "" {
+				return errors.New("empty tag name")
+			}
+
+			// Only the tag name is supported and not a css selector like "div.note"
+			isValid := strings.IndexFunc(tagName, func(r rune) bool {
+				return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-'
+			}) == -1
+			if !isValid {
+				return fmt.Errorf("invalid tag name %q", tagName)
+			}
+
+			*target = append(*target, tagName)
+		}
+		return nil
+	})
+}
+
 func (cli *CLI) singleStringFlag(target *string, name string, usage string) {
 	cli.flags.Func(name, usage, func(flagValue string) error {
 		if strings.TrimSpace(flagValue) == "" {
@@ -55,15 +78,48 @@ func (cli *CLI) initFlags(progname string) {
 		"input",
 		"Read input from FILE instead of stdin",
 	)
+	cli.singleStringFlag(
+		&cli.config.inputURL,
+		"url",
+		"Fetch the input from URL instead of stdin",
+	)
+	cli.singleStringFlag(
+		&cli.config.headerFilepath,
+		"header-file",
+		`[for --url] a file with http headers that are sent with the request, one "Name: Value" per line`,
+	)
+	cli.singleStringFlag(
+		&cli.config.cookieFilepath,
+		"cookie-file",
+		`[for --url] a file with cookies in the "Netscape" format (e.g. exported by curl) that are sent with the request`,
+	)
 	cli.singleStringFlag(
 		&cli.config.outputFilepath,
 		"output",
 		"Write output to FILE instead of stdout",
 	)
 	cli.flags.BoolVar(&cli.config.outputOverwrite, "output-overwrite", false, "replace existing files")
+	cli.flags.BoolVar(&cli.config.linkMapping, "link-mapping", false, `[for --input] rewrite the links between the converted files to point to the ".md" files`)
+
+	cli.singleStringFlag(
+		&cli.config.downloadImagesDir,
+		"download-images",
+		"Download the images into DIR and link to the local files",
+	)
+	cli.singleStringFlag(
+		&cli.config.tablesDir,
+		"tables-dir",
+		"[for --plugin-table] Write every table into DIR as a separate file",
+	)
+	cli.singleStringFlag(
+		&cli.config.tablesFormat,
+		"tables-format",
+		`[for --tables-dir] the file format of the tables: "csv", "tsv" or "json" (default: "csv")`,
+	)
 
 	// TODO: --tag-type-block=script,style (and check that it is not a selector)
 	// TODO: --tag-type-inline=script,style (and check that it is not a selector)
+	cli.tagNamesFlag(&cli.config.tagTypeKeep, "tag-type-keep", `keep these elements as html, e.g. "sup,sub,mark"`)
 
 	cli.flags.StringVar(
 		&cli.config.domain,
@@ -94,7 +150,21 @@ func (cli *CLI) initFlags(progname string) {
 	cli.flags.StringVar(&cli.config.tableSpanCellBehavior, "opt-table-span-cell-behavior", "", `[for --plugin-table] how colspan/rowspan should be rendered: "empty" or "mirror"`)
 	cli.flags.BoolVar(&cli.config.tablePresentationTables, "opt-table-presentation-tables", false, `[for --plugin-table] whether tables with role="presentation" should be converted`)
 	cli.flags.StringVar(&cli.config.tableNewlineBehavior, "opt-table-newline-behavior", "", `[for --plugin-table] how tables containing newlines should be handled: "skip" or "preserve"`)
+	cli.flags.StringVar(&cli.config.tableFallbackBehavior, "opt-table-fallback-behavior", "", `[for --plugin-table] how tables that cannot be converted to markdown should be handled: "skip", "html" or "html_with_markdown"`)
 	cli.flags.StringVar(&cli.config.tableCellPaddingBehavior, "opt-table-cell-padding-behavior", "", `[for --plugin-table] whether cells in the tables should include extra padding for visual continuity: "aligned", "minimal", or "none"`)
+	cli.flags.StringVar(&cli.config.tableFormat, "opt-table-format", "", `[for --plugin-table] the markdown syntax for tables: "pipe", "grid" (pandoc grid tables that support lists and multiple paragraphs) or "auto" (grid tables only for cells with block content)`)
+	cli.flags.IntVar(&cli.config.tableMaxColumnWidth, "opt-table-max-column-width", 0, "[for --plugin-table] the maximum width of the content of a column (default: unlimited)")
+	cli.flags.IntVar(&cli.config.tableMaxTableWidth, "opt-table-max-table-width", 0, "[for --plugin-table] the maximum width of a row in the table (default: unlimited)")
+	cli.flags.StringVar(&cli.config.tableOverflowBehavior, "opt-table-overflow-behavior", "", `[for --plugin-table] what happens with columns that exceed the max width: "truncate", "unpadded" or "skip"`)
+	cli.flags.StringVar(&cli.config.tableRenderMode, "opt-table-render-mode", "", `[for --plugin-table] how the tables should be presented: "table", "list" (every row as a list item), "transposed" (for two columns) or "headings" (every row as a section)`)
+	cli.flags.IntVar(&cli.config.tableLayoutThreshold, "opt-table-layout-threshold", 0, "[for --plugin-table] unwrap tables that are used for layout, once the score of the signals (e.g. a single cell) reaches the threshold. 3 is a good starting point (default: disabled)")
+	cli.flags.BoolVar(&cli.config.tableLayoutDebug, "opt-table-layout-debug", false, "[for --opt-table-layout-threshold] add an html comment explaining why a table was detected as a layout table")
+	cli.flags.StringVar(&cli.config.tableCaptionPosition, "opt-table-caption-position", "", `[for --plugin-table] where the caption should be placed: "below" or "above"`)
+	cli.flags.StringVar(&cli.config.tableCaptionStyle, "opt-table-caption-style", "", `[for --plugin-table] how the caption should be rendered: "plain", "italic", "prefix" (**Table:** caption), "pandoc" (Table: caption) or "comment" (html comment)`)
+	cli.flags.BoolVar(&cli.config.tableCaptionFallback, "opt-table-caption-fallback", false, `[for --plugin-table] use the "aria-labelledby", "aria-label" or "summary" attributes as the caption of tables without a <caption>`)
+	cli.flags.BoolVar(&cli.config.tableMergeHeaderRows, "opt-table-merge-header-rows", false, `[for --plugin-table] merge multiple header rows into compound column names (e.g. "Q1 / Revenue")`)
+	cli.flags.StringVar(&cli.config.tableSectionRowBehavior, "opt-table-section-row-behavior", "", `[for --plugin-table] how rows with a single <th> spanning all columns should be rendered: "keep", "separator" (bold title in the first cell) or "split" (one table per section with a heading)`)
+	cli.flags.BoolVar(&cli.config.tableNumericAlignment, "opt-table-numeric-alignment", false, "[for --plugin-table] right-align the columns that mostly contain numbers")
 }
 
 func (cli *CLI) parseFlags(args []string) error {
@@ -106,6 +176,27 @@ func (cli *CLI) parseFlags(args []string) error {
 	cli.config.args = cli.flags.Args()
 
 	// Validate flag dependencies
+	if cli.config.inputURL != "" && cli.config.inputFilepath != "" {
+		return fmt.Errorf("--url and --input cannot be used together")
+	}
+	if cli.config.headerFilepath != "" && cli.config.inputURL == "" {
+		return fmt.Errorf("--header-file requires --url")
+	}
+	if cli.config.cookieFilepath != "" && cli.config.inputURL == "" {
+		return fmt.Errorf("--cookie-file requires --url")
+	}
+	if cli.config.linkMapping && cli.config.inputFilepath == "" {
+		return fmt.Errorf("--link-mapping requires --input")
+	}
+	if cli.config.tablesDir != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--tables-dir requires --plugin-table to be enabled")
+	}
+	if cli.config.tablesFormat != "" && cli.config.tablesDir == "" {
+		return fmt.Errorf("--tables-format requires --tables-dir")
+	}
+	if !isValidTablesFormat(cli.config.tablesFormat) {
+		return fmt.Errorf(`invalid value %q for --tables-format, expected "csv", "tsv" or "json"`, cli.config.tablesFormat)
+	}
 	if cli.config.tableSkipEmptyRows && !cli.config.enablePluginTable {
 		return fmt.Errorf("--opt-table-skip-empty-rows requires --plugin-table to be enabled")
 	}
@@ -121,9 +212,51 @@ func (cli *CLI) parseFlags(args []string) error {
 	if cli.config.tableNewlineBehavior != "" && !cli.config.enablePluginTable {
 		return fmt.Errorf("--opt-table-newline-behavior requires --plugin-table to be enabled")
 	}
+	if cli.config.tableFallbackBehavior != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-fallback-behavior requires --plugin-table to be enabled")
+	}
 	if cli.config.tableCellPaddingBehavior != "" && !cli.config.enablePluginTable {
 		return fmt.Errorf("--opt-table-cell-padding-behavior requires --plugin-table to be enabled")
 	}
+	if cli.config.tableFormat != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-format requires --plugin-table to be enabled")
+	}
+	if cli.config.tableMaxColumnWidth != 0 && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-max-column-width requires --plugin-table to be enabled")
+	}
+	if cli.config.tableMaxTableWidth != 0 && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-max-table-width requires --plugin-table to be enabled")
+	}
+	if cli.config.tableOverflowBehavior != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-overflow-behavior requires --plugin-table to be enabled")
+	}
+	if cli.config.tableRenderMode != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-render-mode requires --plugin-table to be enabled")
+	}
+	if cli.config.tableLayoutThreshold != 0 && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-layout-threshold requires --plugin-table to be enabled")
+	}
+	if cli.config.tableCaptionPosition != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-caption-position requires --plugin-table to be enabled")
+	}
+	if cli.config.tableCaptionStyle != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-caption-style requires --plugin-table to be enabled")
+	}
+	if cli.config.tableCaptionFallback && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-caption-fallback requires --plugin-table to be enabled")
+	}
+	if cli.config.tableMergeHeaderRows && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-merge-header-rows requires --plugin-table to be enabled")
+	}
+	if cli.config.tableSectionRowBehavior != "" && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-section-row-behavior requires --plugin-table to be enabled")
+	}
+	if cli.config.tableNumericAlignment && !cli.config.enablePluginTable {
+		return fmt.Errorf("--opt-table-numeric-alignment requires --plugin-table to be enabled")
+	}
+	if cli.config.tableLayoutDebug && cli.config.tableLayoutThreshold == 0 {
+		return fmt.Errorf("--opt-table-layout-debug requires --opt-table-layout-threshold")
+	}
 
 	// TODO: use constant for flag name & use formatFlag
 	//       var keyStrongDelimiter = "opt-strong-delimiter"
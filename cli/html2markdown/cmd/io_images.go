@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/imageassets"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// localImageFetcher reads images with a relative path from the
+// folder of the input file. All other images are downloaded.
+type localImageFetcher struct {
+	// dir is the folder of the input file.
+	dir string
+	// root is the folder of the whole input (e.g. "site" for "site/**/*.html").
+	// Root-relative paths are resolved against it and no path can leave it.
+	root string
+
+	remote imageassets.Fetcher
+}
+
+func (f *localImageFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "" || u.Host != "" {
+		return f.remote.Fetch(ctx, rawURL)
+	}
+
+	if f.dir == "" {
+		return nil, errors.New("relative paths can only be used with --input or --domain")
+	}
+
+	base := f.dir
+	if strings.HasPrefix(u.Path, "/") {
+		// e.g. "/images/cat.png" is relative to the root of the website
+		base = f.root
+	}
+	path := filepath.Join(base, filepath.FromSlash(u.Path))
+
+	rel, err := filepath.Rel(f.root, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("the path %q is outside of the input folder", u.Path)
+	}
+	return os.ReadFile(path)
+}
+
+// inputRoot returns the folder that contains all the input files,
+// e.g. "site" for "site/**/*.html" or "site/blog" for "site/blog/post.html".
+func inputRoot(inputFilepath string) string {
+	base, _ := doublestar.SplitPattern(
+		filepath.ToSlash(filepath.Clean(inputFilepath)),
+	)
+	return filepath.FromSlash(base)
+}
+
+// assetsPath returns the path to the images folder,
+// relative to the folder of the markdown file(s).
+func (cli *CLI) assetsPath(outputType outputType) (string, error) {
+	markdownDir := "."
+	switch outputType {
+	case outputTypeDirectory:
+		markdownDir = cli.config.outputFilepath
+	case outputTypeFile:
+		markdownDir = filepath.Dir(cli.config.outputFilepath)
+	}
+
+	from, err := filepath.Abs(markdownDir)
+	if err != nil {
+		return "", err
+	}
+	to, err := filepath.Abs(cli.config.downloadImagesDir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(from, to)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func (cli *CLI) newImageAssetsPlugin(in *input, assetsPath string) (converter.Plugin, error) {
+	client, err := cli.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only files have a folder that the relative paths can be resolved against.
+	var dir, root string
+	if cli.config.inputFilepath != "" {
+		dir = filepath.Dir(in.inputFullFilepath)
+		root = inputRoot(cli.config.inputFilepath)
+	}
+
+	return imageassets.NewImageAssetsPlugin(
+		imageassets.WithFetcher(&localImageFetcher{
+			dir:    dir,
+			root:   root,
+			remote: imageassets.NewHTTPFetcher(client),
+		}),
+		imageassets.WithSink(imageassets.NewDirectorySink(cli.config.downloadImagesDir)),
+		imageassets.WithAssetsPath(assetsPath),
+	), nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecute_DownloadImages(t *testing.T) {
+	directoryPath := newTestDir(t)
+	defer os.RemoveAll(directoryPath)
+
+	chdirWithCleanup(t, directoryPath)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/remote.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("remote image"))
+	}))
+	defer srv.Close()
+
+	err := os.MkdirAll(filepath.Join("site", "images"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join("site", "images", "local.png"), []byte("local image"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := fmt.Sprintf(`<img src="images/local.png" /> <img src="%s/remote.png" /> <img src="images/missing.png" />`, srv.URL)
+	err = os.WriteFile(filepath.Join("site", "index.html"), []byte(input), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// - - - - - - - - - //
+	args := []string{"html2markdown", "--input", filepath.Join("site", "index.html"), "--output", "docs/", "--download-images", "docs/assets/"}
+
+	stdin := &FakeFile{mode: modeTerminal}
+	stdout := &FakeFile{mode: modePipe}
+	stderr := &FakeFile{mode: modePipe}
+
+	Run(stdin, stdout, stderr, args, testRelease)
+
+	expectedStderr := fmt.Sprintf("\nwarning: could not save the image %q: open %s: no such file or directory\n\n", "images/missing.png", filepath.Join("site", "images", "missing.png"))
+	if stderr.String() != expectedStderr {
+		t.Fatalf("expected stderr %q but got %q", expectedStderr, stderr.String())
+	}
+	if len(stdout.Bytes()) != 0 {
+		t.Fatalf("expected no stdout content")
+	}
+	// - - - - - - - - - //
+
+	expectRepresentation(t, filepath.Join(directoryPath, "docs"), `
+.
+├─assets
+│ ├─551ae00208ce36a9.png "remote image"
+│ ├─908950b517b3bf71.png "local image"
+├─index.md "![](assets/908950b517b3bf71.png) ![](assets/551ae00208ce36a9.png) ![](images/missing.png)"
+	`)
+}
+
+func TestExecute_DownloadImages_Paths(t *testing.T) {
+	directoryPath := newTestDir(t)
+	defer os.RemoveAll(directoryPath)
+
+	chdirWithCleanup(t, directoryPath)
+
+	err := os.MkdirAll(filepath.Join("site", "images"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.MkdirAll(filepath.Join("site", "blog"), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join("site", "images", "local.png"), []byte("local image"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile("secret.png", []byte("secret"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `<img src="/images/local.png" /> <img src="../images/local.png" /> <img src="../../secret.png" />`
+	err = os.WriteFile(filepath.Join("site", "blog", "post.html"), []byte(input), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// - - - - - - - - - //
+	args := []string{"html2markdown", "--input", "site/**/*.html", "--output", "docs/", "--download-images", "docs/assets/"}
+
+	stdin := &FakeFile{mode: modeTerminal}
+	stdout := &FakeFile{mode: modePipe}
+	stderr := &FakeFile{mode: modePipe}
+
+	Run(stdin, stdout, stderr, args, testRelease)
+
+	expectedStderr := fmt.Sprintf("\nwarning: could not save the image %q: the path %q is outside of the input folder\n\n", "../../secret.png", "../../secret.png")
+	if stderr.String() != expectedStderr {
+		t.Fatalf("expected stderr %q but got %q", expectedStderr, stderr.String())
+	}
+	// - - - - - - - - - //
+
+	expectRepresentation(t, filepath.Join(directoryPath, "docs"), `
+.
+├─assets
+│ ├─908950b517b3bf71.png "local image"
+├─post.md "![](assets/908950b517b3bf71.png) ![](assets/908950b517b3bf71.png) ![](../../secret.png)"
+	`)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,10 @@ type input struct {
 	inputFullFilepath  string
 	outputFullFilepath string
 	data               []byte
+
+	// domain is used to resolve relative links,
+	// if the user did not provide --domain themselves.
+	domain string
 }
 
 // E.g. "website.html" -> "website"
@@ -25,6 +30,13 @@ func fileNameWithoutExtension(fileName string) string {
 var defaultBasename = "output"
 
 func (cli *CLI) listInputs() ([]*input, error) {
+	if cli.config.inputURL != "" {
+		in, err := cli.fetchInput(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return []*input{in}, nil
+	}
 
 	// NOTE: When both stdin and --input are specified,
 	// the explicit --file argument takes precedence.
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// linkMapper knows which output file every input file is written to.
+// That way the links between the converted files can point
+// to the markdown files instead of the (no longer existing) html files.
+type linkMapper struct {
+	// globBase is the folder that the glob started in. It is treated
+	// as the root of the site for links like "/guide/intro.html".
+	globBase string
+
+	// outputs maps the cleaned input path to the output filename.
+	outputs map[string]string
+}
+
+func newLinkMapper(inputFilepath string, inputs []*input) *linkMapper {
+	globBase, _ := doublestar.SplitPattern(
+		filepath.ToSlash(filepath.Clean(inputFilepath)),
+	)
+
+	outputs := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		outputs[filepath.Clean(input.inputFullFilepath)] = input.outputFullFilepath
+	}
+
+	return &linkMapper{
+		globBase: filepath.FromSlash(globBase),
+		outputs:  outputs,
+	}
+}
+
+// lookup finds the output file for a path. For a directory
+// (e.g. "guide/") the index file is used.
+func (m *linkMapper) lookup(path string) (string, bool) {
+	if strings.HasSuffix(path, "/") {
+		for _, index := range []string{"index.html", "index.htm"} {
+			if output, ok := m.outputs[filepath.Join(path, index)]; ok {
+				return output, true
+			}
+		}
+		return "", false
+	}
+
+	output, ok := m.outputs[filepath.Clean(path)]
+	return output, ok
+}
+
+// urlRewriter returns the rewriter for the links inside of the input file.
+//
+// All output files are written into the same directory, so the
+// relative path to another output file is just its filename.
+func (m *linkMapper) urlRewriter(in *input, addWarning func(err error)) converter.HandleURLRewriteFunc {
+	return func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+		if info.TagName != "a" {
+			return info.URL, true
+		}
+
+		u, err := url.Parse(strings.TrimSpace(info.RawURL))
+		if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+			// Links to other websites, "mailto:" links and links
+			// to a #fragment on the same page stay the same.
+			return info.URL, true
+		}
+
+		// The path of the link is relative to the input file.
+		// Only links starting with "/" are relative to the root of the site.
+		var path string
+		if strings.HasPrefix(u.Path, "/") {
+			path = filepath.Join(m.globBase, filepath.FromSlash(u.Path))
+		} else {
+			path = filepath.Join(filepath.Dir(in.inputFullFilepath), filepath.FromSlash(u.Path))
+		}
+		if strings.HasSuffix(u.Path, "/") {
+			path += "/"
+		}
+
+		output, ok := m.lookup(path)
+		if !ok {
+			addWarning(fmt.Errorf("the link %q in %q points to a file that is not part of the conversion", info.RawURL, in.inputFullFilepath))
+			return info.URL, true
+		}
+
+		link := url.URL{
+			Path:     output,
+			Fragment: u.Fragment,
+		}
+		return link.String(), true
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/table"
+)
+
+type ctxKeyTableCollector struct{}
+
+// tableCollector receives the tables of one input file.
+type tableCollector struct {
+	tables []table.Table
+}
+
+func withTableCollector(ctx context.Context, collector *tableCollector) context.Context {
+	return context.WithValue(ctx, ctxKeyTableCollector{}, collector)
+}
+
+// collectTable is the handler for the table plugin. The same converter setup
+// is also used by "serve", so only contexts with a collector are relevant.
+func collectTable(ctx context.Context, t table.Table) {
+	collector, _ := ctx.Value(ctxKeyTableCollector{}).(*tableCollector)
+	if collector == nil {
+		return
+	}
+	collector.tables = append(collector.tables, t)
+}
+
+func isValidTablesFormat(format string) bool {
+	switch format {
+	case "", "csv", "tsv", "json":
+		return true
+	default:
+		return false
+	}
+}
+
+func (cli *CLI) tablesFormat() string {
+	if cli.config.tablesFormat == "" {
+		return "csv"
+	}
+	return cli.config.tablesFormat
+}
+
+// writeTables writes every table into the --tables-dir,
+// e.g. "index.md" has the tables "index.table-1.csv", "index.table-2.csv", ...
+//
+// The tables are named after the markdown file and not the input file. For inputs
+// with the same name in different directories (e.g. "a/index.html" and "b/index.html")
+// the markdown file already contains a hash of the relative path, so the tables don't collide.
+func (cli *CLI) writeTables(outputType outputType, in *input, tables []table.Table) error {
+	markdownFilename := in.outputFullFilepath
+	if outputType == outputTypeFile {
+		markdownFilename = cli.config.outputFilepath
+	}
+	basename := fileNameWithoutExtension(filepath.Base(markdownFilename))
+
+	format := cli.tablesFormat()
+	for _, t := range tables {
+		var buf bytes.Buffer
+		var err error
+		switch format {
+		case "tsv":
+			err = t.WriteTSV(&buf)
+		case "json":
+			err = t.WriteJSON(&buf)
+		default:
+			err = t.WriteCSV(&buf)
+		}
+		if err != nil {
+			return fmt.Errorf("error while encoding the table: %w", err)
+		}
+
+		filename := fmt.Sprintf("%s.table-%d.%s", basename, t.Index+1, format)
+		path := filepath.Join(cli.config.tablesDir, filename)
+
+		err = WriteFile(path, buf.Bytes(), cli.config.outputOverwrite)
+		if err != nil {
+			if errors.Is(err, os.ErrExist) {
+				return fmt.Errorf("table path %q already exists. Use --output-overwrite to replace existing files", path)
+			}
+
+			return fmt.Errorf("error while writing the table: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecute_TablesDir(t *testing.T) {
+	directoryPath := newTestDir(t)
+	defer os.RemoveAll(directoryPath)
+
+	chdirWithCleanup(t, directoryPath)
+
+	err := os.MkdirAll("site", os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `
+<table>
+	<tr><th>Name</th><th>City</th></tr>
+	<tr><td>Max</td><td>Berlin, Germany</td></tr>
+</table>
+<table>
+	<tr><td>A</td><td>B</td></tr>
+</table>
+	`
+	err = os.WriteFile(filepath.Join("site", "index.html"), []byte(input), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc     string
+		args     []string
+		expected string
+	}{
+		{
+			desc: "csv",
+			args: []string{"--tables-dir", "csv/tables/"},
+			expected: `
+.
+├─index.md "| Name | City            |\n|------|-----------------|\n| Max  | Berlin, Germany |\n\n|   |   |\n|---|---|\n| A | B |"
+├─tables
+│ ├─index.table-1.csv "Name,City\nMax,\"Berlin, Germany\"\n"
+│ ├─index.table-2.csv "A,B\n"
+			`,
+		},
+		{
+			desc: "tsv",
+			args: []string{"--tables-dir", "tsv/tables/", "--tables-format", "tsv"},
+			expected: `
+.
+├─index.md "| Name | City            |\n|------|-----------------|\n| Max  | Berlin, Germany |\n\n|   |   |\n|---|---|\n| A | B |"
+├─tables
+│ ├─index.table-1.tsv "Name\tCity\nMax\tBerlin, Germany\n"
+│ ├─index.table-2.tsv "A\tB\n"
+			`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			args := append([]string{"html2markdown", "--input", filepath.Join("site", "index.html"), "--output", tC.desc + "/", "--plugin-table"}, tC.args...)
+
+			stdin := &FakeFile{mode: modeTerminal}
+			stdout := &FakeFile{mode: modePipe}
+			stderr := &FakeFile{mode: modePipe}
+
+			Run(stdin, stdout, stderr, args, testRelease)
+
+			if len(stderr.Bytes()) != 0 {
+				t.Fatalf("got error: %q", stderr.String())
+			}
+
+			expectRepresentation(t, filepath.Join(directoryPath, tC.desc), tC.expected)
+		})
+	}
+}
+
+func TestExecute_TablesDir_SameBasename(t *testing.T) {
+	directoryPath := newTestDir(t)
+	defer os.RemoveAll(directoryPath)
+
+	chdirWithCleanup(t, directoryPath)
+
+	input := `<table><tr><th>A</th></tr><tr><td>1</td></tr></table>`
+	for _, dir := range []string{"a", "b"} {
+		err := os.MkdirAll(filepath.Join("site", dir), os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join("site", dir, "index.html"), []byte(input), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	args := []string{"html2markdown", "--input", "site/**/*.html", "--output", "docs/", "--plugin-table", "--tables-dir", "docs/tables/"}
+
+	stdin := &FakeFile{mode: modeTerminal}
+	stdout := &FakeFile{mode: modePipe}
+	stderr := &FakeFile{mode: modePipe}
+
+	Run(stdin, stdout, stderr, args, testRelease)
+
+	if len(stderr.Bytes()) != 0 {
+		t.Fatalf("got error: %q", stderr.String())
+	}
+
+	// The tables are named after the markdown files, which
+	// already take the directory of duplicate names into account.
+	expectRepresentation(t, filepath.Join(directoryPath, "docs"), `
+.
+├─index.68243bc953.md "| A |\n|---|\n| 1 |"
+├─index.md "| A |\n|---|\n| 1 |"
+├─tables
+│ ├─index.68243bc953.table-1.csv "A\n1\n"
+│ ├─index.table-1.csv "A\n1\n"
+	`)
+}
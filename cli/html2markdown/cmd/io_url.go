@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+var fetchTimeout = 30 * time.Second
+
+// readHeaderFile reads a file where every line is a http header,
+// e.g. "Authorization: Bearer abc". Empty lines and lines
+// starting with "#" are ignored.
+func readHeaderFile(filename string) (http.Header, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, val, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header in line %d of %q: expected the format \"Name: Value\"", lineNumber, filename)
+		}
+		header.Add(strings.TrimSpace(key), strings.TrimSpace(val))
+	}
+
+	return header, scanner.Err()
+}
+
+// readCookieFile reads a cookie file in the "Netscape" format,
+// which is used by curl and most browser extensions.
+func readCookieFile(filename string) (http.CookieJar, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Curl marks http-only cookies with this special prefix
+		// — otherwise lines starting with "#" are comments.
+		line = strings.TrimPrefix(line, "#HttpOnly_")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("invalid cookie in line %d of %q: expected 7 tab separated fields", lineNumber, filename)
+		}
+		domain, includeSubdomains, cookiePath, secure, expires, name, value := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5], fields[6]
+
+		cookie := &http.Cookie{
+			Name:   name,
+			Value:  value,
+			Path:   cookiePath,
+			Secure: strings.EqualFold(secure, "TRUE"),
+		}
+		if strings.EqualFold(includeSubdomains, "TRUE") {
+			cookie.Domain = domain
+		}
+		if seconds, err := strconv.ParseInt(expires, 10, 64); err == nil && seconds > 0 {
+			cookie.Expires = time.Unix(seconds, 0)
+		}
+
+		scheme := "http"
+		if cookie.Secure {
+			scheme = "https"
+		}
+		jar.SetCookies(&url.URL{
+			Scheme: scheme,
+			Host:   strings.TrimPrefix(domain, "."),
+			Path:   cookiePath,
+		}, []*http.Cookie{cookie})
+	}
+
+	return jar, scanner.Err()
+}
+
+// E.g. "https://example.com/blog/article.html" -> "article"
+func basenameFromURL(u *url.URL) string {
+	basename := fileNameWithoutExtension(path.Base(u.Path))
+	if basename == "" || basename == "." || basename == "/" {
+		return defaultBasename
+	}
+	return basename
+}
+
+func (cli *CLI) newHTTPClient() (*http.Client, error) {
+	client := &http.Client{
+		Timeout: fetchTimeout,
+	}
+
+	if cli.config.cookieFilepath != "" {
+		jar, err := readCookieFile(cli.config.cookieFilepath)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading the cookie file: %w", err)
+		}
+		client.Jar = jar
+	}
+
+	return client, nil
+}
+
+func (cli *CLI) fetchInput(ctx context.Context) (*input, error) {
+	u, err := url.Parse(cli.config.inputURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, NewCLIError(
+			fmt.Errorf("invalid url %q", cli.config.inputURL),
+			Paragraph("The url needs to start with http:// or https://"),
+			CodeBlock(`html2markdown --url "https://example.com"`),
+		)
+	}
+
+	client, err := cli.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if cli.config.headerFilepath != "" {
+		header, err := readHeaderFile(cli.config.headerFilepath)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading the header file: %w", err)
+		}
+		req.Header = header
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", projectBinary+"/"+cli.Release.Version)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching the url: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("error while fetching the url: unexpected status %q", res.Status)
+	}
+
+	// The charset is detected from the "Content-Type" header and the <meta> tags.
+	// The html parser requires utf-8, so everything else is converted.
+	r, err := charset.NewReader(res.Body, res.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("error while detecting the charset: %w", err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading the response: %w", err)
+	}
+
+	// Because of redirects, the final url might be different than the requested one.
+	finalURL := res.Request.URL
+
+	return &input{
+		inputFullFilepath: basenameFromURL(finalURL),
+		data:              data,
+		domain:            finalURL.String(),
+	}, nil
+}
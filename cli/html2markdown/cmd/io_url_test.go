@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestWebsite(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blog/article.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<p>Read the <a href="intro.html">intro</a> and <a href="/about">about</a></p>`)
+	})
+	mux.HandleFunc("/old-article", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/blog/article.html", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/with-base", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head><base href="/docs/v2/"></head><body><a href="intro.html">intro</a></body></html>`)
+	})
+	mux.HandleFunc("/latin1-header", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		// "Grüße" encoded as ISO-8859-1
+		w.Write([]byte("<p>Gr\xfc\xdfe</p>"))
+	})
+	mux.HandleFunc("/latin1-meta", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><meta charset="windows-1252"></head><body><p>Gr` + "\xfc\xdf" + `e</p></body></html>`))
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if r.Header.Get("Authorization") != "Bearer secret" && (err != nil || cookie.Value != "abc") {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `<strong>private content</strong>`)
+	})
+	mux.HandleFunc("/not-found", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func runURLTest(t *testing.T, args []string) (string, string) {
+	t.Helper()
+
+	stdin := &FakeFile{mode: modeTerminal}
+	stdout := &FakeFile{mode: modePipe}
+	stderr := &FakeFile{mode: modePipe}
+
+	Run(stdin, stdout, stderr, append([]string{"html2markdown"}, args...), testRelease)
+
+	return stdout.String(), stderr.String()
+}
+
+func TestExecute_URL(t *testing.T) {
+	srv := newTestWebsite(t)
+
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	headerFile := filepath.Join(dir, "headers.txt")
+	err := os.WriteFile(headerFile, []byte("# a comment\nAuthorization: Bearer secret\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	hostname := host[:strings.LastIndex(host, ":")]
+	cookieFile := filepath.Join(dir, "cookies.txt")
+	err = os.WriteFile(cookieFile, []byte("# Netscape HTTP Cookie File\n"+hostname+"\tFALSE\t/\tFALSE\t0\tsession\tabc\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc string
+		args []string
+
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			desc: "domain from url",
+			args: []string{"--url", srv.URL + "/blog/article.html"},
+
+			expectedStdout: "Read the [intro](" + srv.URL + "/blog/intro.html) and [about](" + srv.URL + "/about)\n",
+		},
+		{
+			desc: "domain from redirected url",
+			args: []string{"--url", srv.URL + "/old-article"},
+
+			expectedStdout: "Read the [intro](" + srv.URL + "/blog/intro.html) and [about](" + srv.URL + "/about)\n",
+		},
+		{
+			desc: "explicit domain takes precedence",
+			args: []string{"--url", srv.URL + "/blog/article.html", "--domain", "https://example.com/blog/"},
+
+			expectedStdout: "Read the [intro](https://example.com/blog/intro.html) and [about](https://example.com/about)\n",
+		},
+		{
+			desc: "base href",
+			args: []string{"--url", srv.URL + "/with-base"},
+
+			expectedStdout: "[intro](" + srv.URL + "/docs/v2/intro.html)\n",
+		},
+		{
+			desc: "base href with include selector",
+			args: []string{"--url", srv.URL + "/with-base", "--include-selector", "a"},
+
+			expectedStdout: "[intro](" + srv.URL + "/docs/v2/intro.html)\n",
+		},
+		{
+			desc: "charset from header",
+			args: []string{"--url", srv.URL + "/latin1-header"},
+
+			expectedStdout: "Grüße\n",
+		},
+		{
+			desc: "charset from meta",
+			args: []string{"--url", srv.URL + "/latin1-meta"},
+
+			expectedStdout: "Grüße\n",
+		},
+		{
+			desc: "header file",
+			args: []string{"--url", srv.URL + "/private", "--header-file", headerFile},
+
+			expectedStdout: "**private content**\n",
+		},
+		{
+			desc: "cookie file",
+			args: []string{"--url", srv.URL + "/private", "--cookie-file", cookieFile},
+
+			expectedStdout: "**private content**\n",
+		},
+
+		{
+			desc: "without credentials",
+			args: []string{"--url", srv.URL + "/private"},
+
+			expectedStderr: "\nerror: error while fetching the url: unexpected status \"403 Forbidden\"\n\n",
+		},
+		{
+			desc: "not found",
+			args: []string{"--url", srv.URL + "/not-found"},
+
+			expectedStderr: "\nerror: error while fetching the url: unexpected status \"404 Not Found\"\n\n",
+		},
+		{
+			desc: "invalid url",
+			args: []string{"--url", "example.com"},
+
+			expectedStderr: "\nerror: invalid url \"example.com\"\n\nThe url needs to start with http:// or https://\n\n    html2markdown --url \"https://example.com\"\n\n",
+		},
+		{
+			desc: "together with input",
+			args: []string{"--url", srv.URL + "/blog/article.html", "--input", "file.html"},
+
+			expectedStderr: "\nerror: --url and --input cannot be used together\n\n",
+		},
+		{
+			desc: "header file without url",
+			args: []string{"--header-file", headerFile},
+
+			expectedStderr: "\nerror: --header-file requires --url\n\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			stdout, stderr := runURLTest(t, tC.args)
+
+			if stderr != tC.expectedStderr {
+				t.Errorf("expected stderr %q but got %q", tC.expectedStderr, stderr)
+			}
+			if stdout != tC.expectedStdout {
+				t.Errorf("expected stdout %q but got %q", tC.expectedStdout, stdout)
+			}
+		})
+	}
+}
+
+func TestExecute_URLWithOutputDirectory(t *testing.T) {
+	srv := newTestWebsite(t)
+
+	directoryPath := newTestDir(t)
+	defer os.RemoveAll(directoryPath)
+
+	stdout, stderr := runURLTest(t, []string{"--url", srv.URL + "/old-article", "--output", directoryPath + "/"})
+	if stderr != "" {
+		t.Fatalf("expected no stderr content but got %q", stderr)
+	}
+	if stdout != "" {
+		t.Fatalf("expected no stdout content but got %q", stdout)
+	}
+
+	expectRepresentation(t, directoryPath, fmt.Sprintf(`
+.
+├─article.md "Read the [intro](%s/blog/intro.html) and [about](%s/about)"
+	`, srv.URL, srv.URL))
+}
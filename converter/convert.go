@@ -12,11 +12,15 @@ import (
 )
 
 type convertOption struct {
-	domain  string
-	context context.Context
+	domain         string
+	context        context.Context
+	warningHandler WarningHandlerFunc
 }
 type ConvertOptionFunc func(o *convertOption)
 
+// WithContext provides a context to the converter. Once the context
+// is canceled (e.g. because of a timeout) the conversion is aborted
+// and the error of the context is returned.
 func WithContext(ctx context.Context) ConvertOptionFunc {
 	return func(o *convertOption) {
 		o.context = ctx
@@ -28,12 +32,25 @@ func WithContext(ctx context.Context) ConvertOptionFunc {
 //
 // If a *relative* url is encountered (in an image or link) then the `domain` is used
 // to convert it to a *absolute* url.
+//
+// If the document contains a `<base href>` element, it is combined with the `domain`
+// in the same way that a browser combines it with the url of the page.
 func WithDomain(domain string) ConvertOptionFunc {
 	return func(o *convertOption) {
 		o.domain = domain
 	}
 }
 
+// WithWarningHandler provides a function that is called for problems
+// that don't abort the conversion (e.g. an image that could not be downloaded).
+//
+// Plugins report these problems with `AddWarning`.
+func WithWarningHandler(fn WarningHandlerFunc) ConvertOptionFunc {
+	return func(o *convertOption) {
+		o.warningHandler = fn
+	}
+}
+
 func (conv *Converter) setError(err error) {
 	conv.m.Lock()
 	defer conv.m.Unlock()
@@ -90,9 +107,11 @@ func (conv *Converter) ConvertNode(doc *html.Node, opts ...ConvertOptionFunc) ([
 		option.context = context.Background()
 	}
 	ctx := option.context
-	ctx = provideDomain(ctx, option.domain)
-	ctx = provideAssembleAbsoluteURL(ctx, defaultAssembleAbsoluteURL)
+	// The <base href> needs to be read before the pre-render handlers
+	// run, since they (e.g. the "base" plugin) remove the <head>.
+	ctx = provideDomain(ctx, resolveBaseDomain(doc, option.domain))
 	ctx = state.provideGlobalState(ctx)
+	ctx = provideWarningHandler(ctx, option.warningHandler)
 
 	customCtx := newConverterContext(ctx, conv)
 
@@ -100,12 +119,20 @@ func (conv *Converter) ConvertNode(doc *html.Node, opts ...ConvertOptionFunc) ([
 
 	// Pre-Render
 	for _, handler := range conv.getPreRenderHandlers() {
+		if err := customCtx.Err(); err != nil {
+			return nil, err
+		}
 		handler.Value(customCtx, doc)
 	}
 
 	// Render
 	var buf bytes.Buffer
 	conv.handleRenderNode(customCtx, &buf, doc)
+	if err := customCtx.Err(); err != nil {
+		// The rendering stops early once the context is canceled,
+		// so the content in the buffer is incomplete.
+		return nil, err
+	}
 
 	// Post-Render
 	result := buf.Bytes()
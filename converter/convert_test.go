@@ -1,6 +1,11 @@
 package converter_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/JohannesKaufmann/dom"
@@ -95,6 +100,200 @@ func TestConvertString_ErrBasePluginMissing(t *testing.T) {
 	}
 }
 
+func TestConvertString_WithContext(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+	)
+
+	t.Run("not canceled", func(t *testing.T) {
+		output, err := conv.ConvertString("<strong>bold text</strong>", converter.WithContext(context.Background()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != "**bold text**" {
+			t.Errorf("expected different output but got %q", output)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		output, err := conv.ConvertString("<strong>bold text</strong>", converter.WithContext(ctx))
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the context error but got %v", err)
+		}
+		if output != "" {
+			t.Errorf("expected empty output but got %q", output)
+		}
+	})
+}
+
+func TestConvertString_BaseHref(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+	)
+
+	input := `<html><head><base href="/docs/v2/"></head><body><a href="intro.html">Intro</a> <img src="/logo.png" /></body></html>`
+
+	output, err := conv.ConvertString(input, converter.WithDomain("https://example.com/blog/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[Intro](https://example.com/docs/v2/intro.html) ![](https://example.com/logo.png)"
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+
+	output, err = conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "[Intro](/docs/v2/intro.html) ![](/logo.png)"
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+
+	// Without a <base href>, a domain that is only a path is ignored
+	output, err = conv.ConvertString(`<a href="intro.html">Intro</a>`, converter.WithDomain("/docs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "[Intro](intro.html)"
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
+
+func TestWithURLRewriter(t *testing.T) {
+	var infos []converter.URLInfo
+
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithURLRewriter(func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+			infos = append(infos, info)
+
+			if strings.Contains(info.RawURL, "ads") {
+				return "", false
+			}
+			if page, ok := strings.CutPrefix(info.RawURL, "/wiki/"); ok {
+				return page + ".md", true
+			}
+			if info.TagName == "img" {
+				return "https://cdn.example.com/?url=" + url.QueryEscape(info.URL), true
+			}
+
+			u, err := url.Parse(info.URL)
+			if err != nil {
+				return info.URL, true
+			}
+			query := u.Query()
+			query.Del("utm_source")
+			u.RawQuery = query.Encode()
+			return u.String(), true
+		}),
+	)
+
+	input := `
+<p><a href="/wiki/Foo">Foo</a></p>
+<p><a href="/article?id=1&utm_source=newsletter">Article</a></p>
+<p><img src="images/cat.png" alt="cat" /></p>
+<p><a href="https://ads.example.com">Sponsored</a> text</p>
+<p><img src="/ads/banner.png" alt="banner" />text</p>
+	`
+	output, err := conv.ConvertString(input, converter.WithDomain("https://example.com/blog/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[Foo](Foo.md)\n\n[Article](https://example.com/article?id=1)\n\n![cat](https://cdn.example.com/?url=https%3A%2F%2Fexample.com%2Fblog%2Fimages%2Fcat.png)\n\nSponsored text\n\ntext"
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+
+	expectedInfo := converter.URLInfo{
+		TagName:   "img",
+		Attribute: "src",
+		RawURL:    "images/cat.png",
+		Base:      "https://example.com/blog/",
+		URL:       "https://example.com/blog/images/cat.png",
+	}
+	if len(infos) != 5 {
+		t.Fatalf("expected 5 calls but got %d", len(infos))
+	}
+	if infos[2] != expectedInfo {
+		t.Errorf("expected %+v but got %+v", expectedInfo, infos[2])
+	}
+}
+
+func TestWithURLRewriter_Priority(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithURLRewriter(func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+			return info.URL + "#standard", true
+		}),
+	)
+	conv.Register.URLRewriter(func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+		return info.URL + "?early", true
+	}, converter.PriorityEarly)
+
+	output, err := conv.ConvertString(`<a href="/page">Page</a>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[Page](/page?early#standard)"
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
+
+func TestWithURLRewriter_AssembleAbsoluteURL(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithURLRewriter(func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+			if strings.Contains(info.RawURL, "ads") {
+				return "", false
+			}
+			return info.URL + "?" + info.Attribute, true
+		}),
+	)
+	conv.Register.RendererFor("video", converter.TagTypeInline, func(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+		src := dom.GetAttributeOr(n, "src", "")
+
+		assembled := ctx.AssembleAbsoluteURL(ctx, "video", src)
+		resolved, keep := converter.ResolveURL(ctx, "video", "src", src)
+
+		fmt.Fprintf(w, "[%s|%s|%t]", assembled, resolved, keep)
+		return converter.RenderSuccess
+	}, converter.PriorityEarly)
+
+	input := `<video src="/movie.mp4"></video><video src="/ads.mp4"></video>`
+	output, err := conv.ConvertString(input, converter.WithDomain("https://example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "[https://example.com/movie.mp4?src|https://example.com/movie.mp4?src|true][||false]"
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
+
 func TestWithEscapeMode(t *testing.T) {
 	mockRenderer := func(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
 		return converter.RenderTryNext
@@ -152,3 +351,152 @@ func TestWithEscapeMode(t *testing.T) {
 		}
 	})
 }
+
+func TestWithWarningHandler(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+	)
+	conv.Register.PreRenderer(func(ctx converter.Context, doc *html.Node) {
+		converter.AddWarning(ctx, errors.New("something is off"))
+	}, converter.PriorityStandard)
+
+	var warnings []error
+	output, err := conv.ConvertString("<strong>bold</strong>", converter.WithWarningHandler(func(err error) {
+		warnings = append(warnings, err)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != "**bold**" {
+		t.Errorf("expected different output but got %q", output)
+	}
+	if len(warnings) != 1 || warnings[0].Error() != "something is off" {
+		t.Errorf("expected one warning but got %v", warnings)
+	}
+
+	// Without a handler the warnings are ignored
+	_, err = conv.ConvertString("<strong>bold</strong>")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithAllowedURLSchemes(t *testing.T) {
+	input := `
+<p><a href="javascript:alert(1)">Click</a> and <a href="https://example.com">Safe</a></p>
+<p><a href="&amp;#106;avascript:alert(1)">Encoded</a></p>
+<p><img src="data:text/html;base64,PHNjcmlwdD4=" alt="html" />text</p>
+<p><img src="data:image/gif;base64,R0lGODlh" alt="gif" /></p>
+<p><a href="/relative">Relative</a></p>
+`
+	testCases := []struct {
+		desc     string
+		options  []func(*converter.Converter) error
+		expected string
+	}{
+		{
+			desc:     "default allows everything",
+			options:  nil,
+			expected: "[Click](javascript:alert%281%29) and [Safe](https://example.com)\n\n[Encoded](&#106;avascript:alert%281%29)\n\n![html](data:text/html;base64,PHNjcmlwdD4=)text\n\n![gif](data:image/gif;base64,R0lGODlh)\n\n[Relative](/relative)",
+		},
+		{
+			desc: "strip",
+			options: []func(*converter.Converter) error{
+				converter.WithAllowedURLSchemes(converter.DefaultAllowedURLSchemes...),
+			},
+			expected: "Click and [Safe](https://example.com)\n\nEncoded\n\ntext\n\n![gif](data:image/gif;base64,R0lGODlh)\n\n[Relative](/relative)",
+		},
+		{
+			desc: "neutralize",
+			options: []func(*converter.Converter) error{
+				converter.WithAllowedURLSchemes("https"),
+				converter.WithDisallowedURLBehavior(converter.DisallowedURLBehaviorNeutralize),
+			},
+			expected: "[Click](#) and [Safe](https://example.com)\n\n[Encoded](#)\n\n![html](#)text\n\n![gif](#)\n\n[Relative](/relative)",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			options := append([]func(*converter.Converter) error{
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+				),
+			}, tC.options...)
+			conv := converter.NewConverter(options...)
+
+			output, err := conv.ConvertString(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output != tC.expected {
+				t.Errorf("expected %q but got %q", tC.expected, output)
+			}
+		})
+	}
+}
+
+func TestWithAllowedURLSchemes_RawHTML(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithAllowedURLSchemes("https"),
+	)
+	conv.Register.RendererFor("div", converter.TagTypeBlock, base.RenderAsHTML, converter.PriorityEarly)
+
+	input := `<div onclick="alert(1)"><a href="javascript:alert(1)" title="x">A</a> <img src="https://example.com/a.png" srcset="https://example.com/b.png 2x, javascript:alert(1) 3x" OnError="alert(1)" /> <img src="https://example.com/c.png" srcset="https://example.com/c.png 1x,javascript:alert(1) 2x" /></div>`
+	expected := `<div><a title="x">A</a> <img src="https://example.com/a.png"/> <img src="https://example.com/c.png"/></div>`
+
+	output, err := conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
+
+func TestRenderHTML_EventHandlers(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+	)
+	conv.Register.RendererFor("div", converter.TagTypeBlock, base.RenderAsHTML, converter.PriorityEarly)
+
+	input := `<div onclick="alert(1)"><a href="/page" title="x">A</a> <img src="a.png" OnError="alert(1)" /></div>`
+	expected := `<div><a href="/page" title="x">A</a> <img src="a.png"/></div>`
+
+	output, err := conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
+
+func TestWithDisallowedURLBehavior_Invalid(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithDisallowedURLBehavior("remove"),
+	)
+
+	_, err := conv.ConvertString("<p>text</p>")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `unknown value "remove" for disallowed url behavior`
+	if err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
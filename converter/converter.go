@@ -1,6 +1,11 @@
 package converter
 
-import "sync"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 type Converter struct {
 	m sync.RWMutex
@@ -18,7 +23,12 @@ type Converter struct {
 	markdownChars    map[rune]interface{}
 	unEscapeHandlers prioritizedSlice[HandleUnEscapeFunc]
 
-	tagTypes map[string]prioritizedSlice[tagType]
+	urlRewriteHandlers    prioritizedSlice[HandleURLRewriteFunc]
+	allowedURLSchemes     []string
+	disallowedURLBehavior disallowedURLBehavior
+
+	tagTypes      map[string]prioritizedSlice[tagType]
+	keepAllowlist map[string][]string
 
 	escapeMode escapeMode
 
@@ -70,3 +80,57 @@ func WithEscapeMode(mode escapeMode) converterOption {
 		return nil
 	}
 }
+
+// WithURLRewriter registers a function that can change the url
+// of every link and image (e.g. to strip tracking parameters or
+// to point to a different file).
+//
+// The function receives the already absolute url and returns the
+// replacement. By returning false the link or image is dropped.
+//
+// See `URLInfo` for the details that are available.
+func WithURLRewriter(fn HandleURLRewriteFunc) converterOption {
+	return func(c *Converter) error {
+		c.Register.URLRewriter(fn, PriorityStandard)
+		return nil
+	}
+}
+
+// WithAllowedURLSchemes restricts the urls of links and images (and of the html
+// that is kept) to these schemes, e.g. to prevent "javascript:" links when converting
+// untrusted html. Relative urls are always allowed. See `DefaultAllowedURLSchemes`
+// for a good starting point.
+//
+// By default every scheme is allowed. The html of `TagTypeKeep`
+// is always restricted to the `DefaultAllowedURLSchemes`.
+func WithAllowedURLSchemes(schemes ...string) converterOption {
+	return func(c *Converter) error {
+		c.allowedURLSchemes = make([]string, 0, len(schemes))
+		for _, scheme := range schemes {
+			scheme = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(scheme), ":"))
+			if scheme == "" {
+				return errors.New("the url scheme can not be empty")
+			}
+			c.allowedURLSchemes = append(c.allowedURLSchemes, scheme)
+		}
+		return nil
+	}
+}
+
+// WithDisallowedURLBehavior configures what happens with urls that
+// are not allowed by `WithAllowedURLSchemes`.
+//
+//	"strip" or "neutralize"
+//
+//	default: "strip"
+func WithDisallowedURLBehavior(behavior disallowedURLBehavior) converterOption {
+	return func(c *Converter) error {
+		switch behavior {
+		case DisallowedURLBehaviorStrip, DisallowedURLBehaviorNeutralize:
+			c.disallowedURLBehavior = behavior
+			return nil
+		default:
+			return fmt.Errorf("unknown value %q for disallowed url behavior", behavior)
+		}
+	}
+}
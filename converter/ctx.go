@@ -3,6 +3,7 @@ package converter
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/JohannesKaufmann/dom"
 	"golang.org/x/net/html"
@@ -19,8 +20,8 @@ import (
 type ctxKey string
 
 const (
-	ctxKeyAssembleAbsoluteURL ctxKey = "AssembleAbsoluteURL"
-	ctxKeyDomain              ctxKey = "Domain"
+	ctxKeyDomain         ctxKey = "Domain"
+	ctxKeyWarningHandler ctxKey = "WarningHandler"
 
 	ctxKeySetState    ctxKey = "SetState"
 	ctxKeyUpdateState ctxKey = "UpdateState"
@@ -42,23 +43,102 @@ func GetDomain(ctx context.Context) string {
 
 // - - - - - - - - - - - - - - - - - - - - - //
 
+// AssembleAbsoluteURLFunc is the signature of a function
+// that converts the url to an absolute url.
+//
+// Deprecated: The url of a link or image can be changed with `WithURLRewriter`.
 type AssembleAbsoluteURLFunc func(tagName string, rawURL string, domain string) string
 
 func assembleAbsoluteURL(ctx context.Context, tagName string, rawURL string) string {
-	domain := GetDomain(ctx)
+	return defaultAssembleAbsoluteURL(tagName, rawURL, GetDomain(ctx))
+}
+
+// resolveURL assembles the absolute url and then passes it
+// through all the registered url rewriters.
+func (conv *Converter) resolveURL(ctx Context, tagName string, attribute string, rawURL string) (string, bool) {
+	info := URLInfo{
+		TagName:   tagName,
+		Attribute: attribute,
+		RawURL:    rawURL,
+		Base:      GetDomain(ctx),
+		URL:       assembleAbsoluteURL(ctx, tagName, rawURL),
+	}
+
+	for _, handler := range conv.getURLRewriteHandlers() {
+		newURL, keep := handler.Value(ctx, info)
+		if !keep {
+			return "", false
+		}
+		info.URL = newURL
+	}
+
+	// The allowlist is checked last, so that a rewriter
+	// can not bring back a disallowed url.
+	return conv.checkURL(info.URL, false)
+}
 
-	// TODO: since this gets passed down from the converter, it doesn't have to provided from the ctx anymore
-	fn, ok := ctx.Value(ctxKeyAssembleAbsoluteURL).(AssembleAbsoluteURLFunc)
+// urlResolver is implemented by the Context of the converter.
+// It is not part of the Context interface, so that
+// other implementations of the interface keep working.
+type urlResolver interface {
+	resolveURL(ctx Context, tagName string, attribute string, rawURL string) (string, bool)
+}
+
+// ResolveURL is like AssembleAbsoluteURL but reports whether a url rewriter
+// (see `WithURLRewriter`) dropped the url. If the returned bool is false,
+// the element with that url should be dropped.
+func ResolveURL(ctx Context, tagName string, attribute string, rawURL string) (string, bool) {
+	resolver, ok := ctx.(urlResolver)
 	if !ok {
-		fmt.Println("[warning] func ctxKeyAssembleAbsoluteURL is different")
-		return ""
+		return ctx.AssembleAbsoluteURL(ctx, tagName, rawURL), true
+	}
+	return resolver.resolveURL(ctx, tagName, attribute, rawURL)
+}
+
+// htmlRenderer is implemented by the Context of the converter.
+// Like urlResolver it is not part of the Context interface.
+type htmlRenderer interface {
+	renderHTML(w io.Writer, n *html.Node) error
+}
+
+// RenderHTML renders the node as html. The urls in the attributes
+// are checked against the allowlist of `WithAllowedURLSchemes`
+// and the event handler attributes (e.g. "onerror") are removed.
+func RenderHTML(ctx Context, w io.Writer, n *html.Node) error {
+	renderer, ok := ctx.(htmlRenderer)
+	if !ok {
+		return html.Render(w, cloneWithAttributes(n, removeEventHandlers))
 	}
+	return renderer.renderHTML(w, n)
+}
 
-	return fn(tagName, rawURL, domain)
+// urlAttribute returns the attribute that contains the url of the element.
+func urlAttribute(tagName string) string {
+	switch tagName {
+	case "img", "source", "video", "audio", "iframe", "embed", "script":
+		return "src"
+	default:
+		return "href"
+	}
 }
 
-func provideAssembleAbsoluteURL(ctx context.Context, fn AssembleAbsoluteURLFunc) context.Context {
-	return context.WithValue(ctx, ctxKeyAssembleAbsoluteURL, fn)
+// - - - - - - - - - - - - - - - - - - - - - //
+
+type WarningHandlerFunc func(err error)
+
+func provideWarningHandler(ctx context.Context, fn WarningHandlerFunc) context.Context {
+	return context.WithValue(ctx, ctxKeyWarningHandler, fn)
+}
+
+// AddWarning reports a problem to the function from `WithWarningHandler`.
+// Without a warning handler, the warning is ignored.
+func AddWarning(ctx context.Context, err error) {
+	fn, _ := ctx.Value(ctxKeyWarningHandler).(WarningHandlerFunc)
+	if fn == nil || err == nil {
+		return
+	}
+
+	fn(err)
 }
 
 // - - - - - - - - - - - - - - - - - - - - - //
@@ -136,6 +216,10 @@ func UpdateState[V any](ctx context.Context, key string, fn func(V) V) {
 type Context interface {
 	context.Context
 
+	// AssembleAbsoluteURL converts the url to an absolute url (see `WithDomain`)
+	// and applies the url rewriters (see `WithURLRewriter`). If a rewriter
+	// dropped the url, an empty string is returned. Use `ResolveURL` to
+	// tell a dropped url apart from an empty url.
 	AssembleAbsoluteURL(ctx Context, tagName string, rawURL string) string
 
 	GetTagType(tagName string) (tagType, bool)
@@ -162,7 +246,16 @@ func newConverterContext(ctx context.Context, conv *Converter) Context {
 }
 
 func (c *converterContext) AssembleAbsoluteURL(ctx Context, tagName string, rawURL string) string {
-	return assembleAbsoluteURL(ctx, tagName, rawURL)
+	u, _ := c.conv.resolveURL(ctx, tagName, urlAttribute(tagName), rawURL)
+	return u
+}
+
+func (c *converterContext) resolveURL(ctx Context, tagName string, attribute string, rawURL string) (string, bool) {
+	return c.conv.resolveURL(ctx, tagName, attribute, rawURL)
+}
+
+func (c *converterContext) renderHTML(w io.Writer, n *html.Node) error {
+	return c.conv.renderHTML(w, n)
 }
 
 func (c *converterContext) RenderNodes(ctx Context, w Writer, nodes ...*html.Node) {
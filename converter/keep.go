@@ -0,0 +1,296 @@
+package converter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/textutils"
+	"golang.org/x/net/html"
+)
+
+// defaultKeepAllowlist contains the elements that can be part of the
+// html that is kept by `TagTypeKeep` together with their allowed attributes.
+// The attributes of "*" are allowed on every element.
+var defaultKeepAllowlist = map[string][]string{
+	"*": {"title", "lang", "dir"},
+
+	"a":   {"href"},
+	"img": {"src", "alt", "width", "height"},
+
+	"b": {}, "i": {}, "em": {}, "strong": {}, "small": {}, "s": {}, "u": {},
+	"sup": {}, "sub": {}, "mark": {}, "ins": {}, "del": {},
+	"code": {}, "kbd": {}, "samp": {}, "var": {},
+	"abbr": {}, "cite": {}, "dfn": {}, "q": {"cite"}, "time": {"datetime"},
+	"span": {}, "br": {}, "wbr": {}, "bdi": {}, "bdo": {},
+	"ruby": {}, "rt": {}, "rp": {},
+
+	"p": {}, "div": {}, "blockquote": {"cite"}, "pre": {}, "hr": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"ul": {}, "ol": {"start", "reversed", "type"}, "li": {"value"},
+	"dl": {}, "dt": {}, "dd": {},
+	"figure": {}, "figcaption": {}, "details": {"open"}, "summary": {},
+	"section": {}, "article": {}, "aside": {}, "header": {}, "footer": {},
+	"nav": {}, "main": {}, "hgroup": {}, "address": {},
+
+	"table": {}, "caption": {}, "thead": {}, "tbody": {}, "tfoot": {}, "tr": {},
+	"th":       {"colspan", "rowspan", "scope", "headers", "abbr", "align"},
+	"td":       {"colspan", "rowspan", "headers", "align"},
+	"colgroup": {"span"}, "col": {"span"},
+
+	"picture": {}, "source": {"src", "type", "media"},
+	"video": {"src", "poster", "controls", "width", "height"},
+	"audio": {"src", "controls"},
+}
+
+// keepRemovedElements are removed together with their content,
+// even if they are part of the allowlist.
+var keepRemovedElements = map[string]struct{}{
+	"script": {}, "style": {}, "template": {}, "noscript": {},
+	"iframe": {}, "frame": {}, "frameset": {}, "object": {}, "embed": {},
+	"form": {}, "input": {}, "button": {}, "select": {}, "textarea": {},
+	"link": {}, "meta": {}, "base": {},
+}
+
+// WithKeepAllowlist changes which elements (and attributes) can be part of the
+// html that is kept by `TagTypeKeep`. The key is the tag name and the value
+// the allowed attributes. The attributes of the key "*" are allowed on every element.
+//
+// Elements that are not in the allowlist are unwrapped, only their content is kept.
+// Attributes that are not in the allowlist are removed.
+func WithKeepAllowlist(allowlist map[string][]string) converterOption {
+	return func(c *Converter) error {
+		c.keepAllowlist = allowlist
+		return nil
+	}
+}
+
+func (conv *Converter) getKeepAllowlist() map[string][]string {
+	conv.m.RLock()
+	defer conv.m.RUnlock()
+
+	if conv.keepAllowlist == nil {
+		return defaultKeepAllowlist
+	}
+	return conv.keepAllowlist
+}
+
+func isAttributeAllowed(allowlist map[string][]string, tagName string, key string) bool {
+	for _, allowed := range allowlist["*"] {
+		if allowed == key {
+			return true
+		}
+	}
+	for _, allowed := range allowlist[tagName] {
+		if allowed == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeAttributes returns a copy of the allowed attributes.
+func (conv *Converter) sanitizeAttributes(ctx Context, allowlist map[string][]string, n *html.Node) []html.Attribute {
+	tagName := dom.NodeName(n)
+
+	var attrs []html.Attribute
+	for _, attr := range n.Attr {
+		if attr.Namespace != "" || !isAttributeAllowed(allowlist, tagName, attr.Key) {
+			continue
+		}
+		if isEventHandler(attr.Key) {
+			// Event handlers are never kept, even if they are in the allowlist.
+			continue
+		}
+
+		if attr.Key == "srcset" {
+			if !conv.isAllowedSrcset(attr.Val, true) {
+				continue
+			}
+		} else if _, isURL := urlAttributes[attr.Key]; isURL {
+			val, keep := conv.resolveURL(ctx, tagName, attr.Key, attr.Val)
+			if keep {
+				// Unlike markdown, the html is always restricted to safe urls.
+				val, keep = conv.checkURL(val, true)
+			}
+			if !keep {
+				continue
+			}
+			attr.Val = val
+		}
+
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// sanitizeChildren appends sanitized copies of the children of `n` to `parent`.
+func (conv *Converter) sanitizeChildren(ctx Context, allowlist map[string][]string, parent *html.Node, n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case html.TextNode:
+			parent.AppendChild(&html.Node{
+				Type: html.TextNode,
+				Data: child.Data,
+			})
+
+		case html.ElementNode:
+			name := dom.NodeName(child)
+			if _, remove := keepRemovedElements[name]; remove {
+				continue
+			}
+			if _, allowed := allowlist[name]; !allowed {
+				// Unwrap the element but keep the content
+				conv.sanitizeChildren(ctx, allowlist, parent, child)
+				continue
+			}
+
+			clone := &html.Node{
+				Type:     html.ElementNode,
+				Data:     child.Data,
+				DataAtom: child.DataAtom,
+				Attr:     conv.sanitizeAttributes(ctx, allowlist, child),
+			}
+			conv.sanitizeChildren(ctx, allowlist, clone, child)
+			parent.AppendChild(clone)
+		}
+
+		// Comments, doctypes, ... are not kept.
+	}
+}
+
+// htmlBlockStartCondition6 are the tag names that start an html block
+// that can interrupt a paragraph and ends with a blank line.
+//
+// https://spec.commonmark.org/0.31.2/#html-blocks
+var htmlBlockStartCondition6 = map[string]struct{}{
+	"address": {}, "article": {}, "aside": {}, "base": {}, "basefont": {}, "blockquote": {},
+	"body": {}, "caption": {}, "center": {}, "col": {}, "colgroup": {}, "dd": {}, "details": {},
+	"dialog": {}, "dir": {}, "div": {}, "dl": {}, "dt": {}, "fieldset": {}, "figcaption": {},
+	"figure": {}, "footer": {}, "form": {}, "frame": {}, "frameset": {}, "h1": {}, "h2": {},
+	"h3": {}, "h4": {}, "h5": {}, "h6": {}, "head": {}, "header": {}, "hr": {}, "html": {},
+	"iframe": {}, "legend": {}, "li": {}, "link": {}, "main": {}, "menu": {}, "menuitem": {},
+	"nav": {}, "noframes": {}, "ol": {}, "optgroup": {}, "option": {}, "p": {}, "param": {},
+	"search": {}, "section": {}, "summary": {}, "table": {}, "tbody": {}, "td": {}, "tfoot": {},
+	"th": {}, "thead": {}, "title": {}, "tr": {}, "track": {}, "ul": {},
+}
+
+// htmlBlockStartCondition1 are the tag names of html blocks
+// that only end with the closing tag, so they can contain blank lines.
+var htmlBlockStartCondition1 = map[string]struct{}{
+	"pre": {}, "script": {}, "style": {}, "textarea": {},
+}
+
+func renderToString(n *html.Node) string {
+	var buf bytes.Buffer
+	html.Render(&buf, n)
+	return buf.String()
+}
+
+// renderStartTag renders only the start tag, e.g. `<sup title="x">`
+func renderStartTag(n *html.Node) string {
+	shallow := &html.Node{
+		Type:     n.Type,
+		Data:     n.Data,
+		DataAtom: n.DataAtom,
+		Attr:     n.Attr,
+	}
+	return strings.TrimSuffix(renderToString(shallow), "</"+n.Data+">")
+}
+
+// renderKeepInline renders the start and end tag while the text in between
+// is rendered (and escaped) like any other markdown text. The children
+// that are in the allowlist are kept as html as well.
+func (conv *Converter) renderKeepInline(ctx Context, w Writer, allowlist map[string][]string, node *html.Node, attrs []html.Attribute) {
+	startTag := renderStartTag(&html.Node{
+		Type:     html.ElementNode,
+		Data:     node.Data,
+		DataAtom: node.DataAtom,
+		Attr:     attrs,
+	})
+	w.WriteString(startTag)
+	if strings.HasSuffix(startTag, "/>") {
+		// A void element (e.g. <br/>) has no content and no end tag
+		return
+	}
+
+	conv.renderKeepInlineChildren(ctx, w, allowlist, node)
+
+	w.WriteString("</" + node.Data + ">")
+}
+func (conv *Converter) renderKeepInlineChildren(ctx Context, w Writer, allowlist map[string][]string, node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case html.TextNode:
+			ctx.RenderNodes(ctx, w, child)
+
+		case html.ElementNode:
+			name := dom.NodeName(child)
+			if _, remove := keepRemovedElements[name]; remove {
+				continue
+			}
+			if _, allowed := allowlist[name]; !allowed {
+				// Unwrap the element but keep the content
+				conv.renderKeepInlineChildren(ctx, w, allowlist, child)
+				continue
+			}
+
+			conv.renderKeepInline(ctx, w, allowlist, child, conv.sanitizeAttributes(ctx, allowlist, child))
+		}
+	}
+}
+
+func (conv *Converter) handleRenderKeep(ctx Context, w Writer, node *html.Node) RenderStatus {
+	allowlist := conv.getKeepAllowlist()
+	tagName := dom.NodeName(node)
+
+	// The element itself is checked like its children, so that
+	// e.g. a kept <script> can not end up in the output.
+	if _, remove := keepRemovedElements[tagName]; remove {
+		return RenderSuccess
+	}
+	if _, allowed := allowlist[tagName]; !allowed {
+		// Unwrap the element but keep the content
+		ctx.RenderChildNodes(ctx, w, node)
+		return RenderSuccess
+	}
+
+	attrs := conv.sanitizeAttributes(ctx, allowlist, node)
+
+	if !dom.NameIsBlockNode(tagName) {
+		// Inside of inline html the text is still interpreted as markdown
+		// and needs to be escaped.
+		conv.renderKeepInline(ctx, w, allowlist, node, attrs)
+		return RenderSuccess
+	}
+
+	// Inside of an html block the content is NOT interpreted as markdown,
+	// so the children are rendered as html as well.
+	clone := &html.Node{
+		Type:     html.ElementNode,
+		Data:     node.Data,
+		DataAtom: node.DataAtom,
+		Attr:     attrs,
+	}
+	conv.sanitizeChildren(ctx, allowlist, clone, node)
+	content := renderToString(clone)
+
+	if _, ok := htmlBlockStartCondition1[tagName]; !ok {
+		content = textutils.EncodeBlankLines(content)
+
+		if _, ok := htmlBlockStartCondition6[tagName]; !ok {
+			// For all other tag names the start tag must be
+			// on a line by itself to start an html block.
+			startTag := renderStartTag(clone)
+			if rest := strings.TrimPrefix(content, startTag); rest != "" && !strings.HasPrefix(rest, "\n") {
+				content = startTag + "\n" + rest
+			}
+		}
+	}
+
+	w.WriteString("\n\n")
+	w.WriteString(content)
+	w.WriteString("\n\n")
+
+	return RenderSuccess
+}
@@ -0,0 +1,195 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func TestTagTypeKeep(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		keep     []string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "inline element",
+			keep:     []string{"sup"},
+			input:    `<p>E = mc<sup>2</sup></p>`,
+			expected: `E = mc<sup>2</sup>`,
+		},
+		{
+			desc:     "inline element with markdown content",
+			keep:     []string{"mark"},
+			input:    `<p>Some <mark>important <b>bold</b> *text*</mark> here</p>`,
+			expected: `Some <mark>important <b>bold</b> \*text\*</mark> here`,
+		},
+		{
+			desc:     "void element",
+			keep:     []string{"br"},
+			input:    `<p>A<br>B</p>`,
+			expected: `A<br/>B`,
+		},
+		{
+			desc:     "overrides the renderer",
+			keep:     []string{"strong"},
+			input:    `<p>Some <strong>bold</strong> text</p>`,
+			expected: `Some <strong>bold</strong> text`,
+		},
+		{
+			desc:     "attributes are sanitized",
+			keep:     []string{"sup"},
+			input:    `<p>A<sup title="note" class="x" onclick="alert(1)" style="color:red">1</sup></p>`,
+			expected: `A<sup title="note">1</sup>`,
+		},
+		{
+			desc:     "block element",
+			keep:     []string{"table"},
+			input:    `<p>Before</p><table border="1"><tr><td>A</td><td>*B*</td></tr></table><p>After</p>`,
+			expected: "Before\n\n<table><tbody><tr><td>A</td><td>*B*</td></tr></tbody></table>\n\nAfter",
+		},
+		{
+			desc:     "inline element with html content",
+			keep:     []string{"ruby"},
+			input:    `<p><ruby>漢<rp>(</rp><rt>kan</rt><rp>)</rp></ruby></p>`,
+			expected: `<ruby>漢<rp>(</rp><rt>kan</rt><rp>)</rp></ruby>`,
+		},
+		{
+			desc:     "block element inside of a paragraph",
+			keep:     []string{"section"},
+			input:    `<span>Before <section>Inside</section> After</span>`,
+			expected: "Before\n\n<section>Inside</section>\n\nAfter",
+		},
+		{
+			desc:     "block element without blank lines",
+			keep:     []string{"section"},
+			input:    "<section><p>A</p><pre>code\n\n  \nblock</pre><p>B</p></section>",
+			expected: "<section><p>A</p><pre>code\n&#10;  &#10;block</pre><p>B</p></section>",
+		},
+		{
+			desc:     "block element with the start tag on its own line",
+			keep:     []string{"hgroup"},
+			input:    `<p>A</p><hgroup><h1>Title</h1><p>Subtitle</p></hgroup>`,
+			expected: "A\n\n<hgroup>\n<h1>Title</h1><p>Subtitle</p></hgroup>",
+		},
+		{
+			desc:     "children are sanitized",
+			keep:     []string{"div"},
+			input:    `<div><custom-element>Text <a href="javascript:alert(1)">link</a></custom-element><script>alert(1)</script><iframe src="/frame"></iframe></div>`,
+			expected: `<div>Text <a>link</a></div>`,
+		},
+		{
+			desc:     "urls are resolved",
+			keep:     []string{"figure"},
+			input:    `<base href="https://example.com/docs/" /><figure><img src="image.png" alt="An image" onerror="alert(1)" /></figure>`,
+			expected: `<figure><img src="https://example.com/docs/image.png" alt="An image"/></figure>`,
+		},
+		{
+			desc:     "pre element can contain blank lines",
+			keep:     []string{"pre"},
+			input:    "<pre>A\n\nB</pre>",
+			expected: "<pre>A\n\nB</pre>",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+				),
+			)
+			for _, tagName := range tC.keep {
+				conv.Register.TagType(tagName, converter.TagTypeKeep, converter.PriorityEarly)
+			}
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output != tC.expected {
+				t.Errorf("expected %q but got %q", tC.expected, output)
+			}
+		})
+	}
+}
+
+func TestWithKeepAllowlist(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithKeepAllowlist(map[string][]string{
+			"*":    {"class"},
+			"div":  {},
+			"span": {"data-id", "onclick"},
+		}),
+	)
+	conv.Register.TagType("div", converter.TagTypeKeep, converter.PriorityEarly)
+
+	input := `<div class="note" title="Note"><span class="a" data-id="1" onclick="alert(1)">A</span> <b>B</b></div>`
+	expected := `<div class="note"><span class="a" data-id="1">A</span> B</div>`
+
+	output, err := conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
+
+func TestTagTypeKeep_RemovedElements(t *testing.T) {
+	for _, tagName := range []string{"script", "style", "iframe"} {
+		t.Run(tagName, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+				),
+			)
+			conv.Register.TagType(tagName, converter.TagTypeKeep, converter.PriorityEarly)
+
+			expectedMessage := `the element "` + tagName + `" can not be kept as html`
+			output, err := conv.ConvertString(`<p>hi<script>alert(1)</script></p>`)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if err.Error() != expectedMessage {
+				t.Errorf("expected %q but got %q", expectedMessage, err.Error())
+			}
+			if output != "" {
+				t.Errorf("expected empty output but got %q", output)
+			}
+		})
+	}
+}
+
+func TestTagTypeKeep_NotInAllowlist(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+		converter.WithKeepAllowlist(map[string][]string{
+			"span": {},
+		}),
+	)
+	conv.Register.TagType("section", converter.TagTypeKeep, converter.PriorityEarly)
+	conv.Register.TagType("span", converter.TagTypeKeep, converter.PriorityEarly)
+
+	input := `<section><span>A</span> <b>B</b></section>`
+	expected := `<span>A</span> **B**`
+
+	output, err := conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output != expected {
+		t.Errorf("expected %q but got %q", expected, output)
+	}
+}
@@ -181,6 +181,51 @@ func (conv *Converter) getUnEscapeHandlers() prioritizedSlice[HandleUnEscapeFunc
 	return handlers
 }
 
+// - - - - - - - - - - - - - URL Rewrite - - - - - - - - - - - - - //
+
+// URLInfo contains the details about a url inside an element
+// (e.g. the "href" of a link) that are passed to the url rewriters.
+type URLInfo struct {
+	// TagName is the name of the element, for example "a" or "img".
+	TagName string
+	// Attribute is the name of the attribute, for example "href" or "src".
+	Attribute string
+
+	// RawURL is the value of the attribute, as written in the html.
+	RawURL string
+	// Base is the url that relative urls are resolved against.
+	// That is the domain (see `WithDomain`) combined with the <base href>.
+	// It can be empty.
+	Base string
+
+	// URL is the absolute url that would be used in the markdown.
+	// If multiple rewriters are registered, it contains the result
+	// of the previous rewriter.
+	URL string
+}
+
+// HandleURLRewriteFunc returns the new url. If the returned bool is false,
+// the link or image is dropped.
+type HandleURLRewriteFunc func(ctx Context, info URLInfo) (string, bool)
+
+func (r *register) URLRewriter(fn HandleURLRewriteFunc, priority int) {
+	r.conv.m.Lock()
+	defer r.conv.m.Unlock()
+
+	handler := prioritized(fn, priority)
+	r.conv.urlRewriteHandlers = append(r.conv.urlRewriteHandlers, handler)
+}
+func (conv *Converter) getURLRewriteHandlers() prioritizedSlice[HandleURLRewriteFunc] {
+	conv.m.RLock()
+	defer conv.m.RUnlock()
+
+	handlers := make(prioritizedSlice[HandleURLRewriteFunc], len(conv.urlRewriteHandlers))
+	copy(handlers, conv.urlRewriteHandlers)
+	handlers.Sort()
+
+	return handlers
+}
+
 // - - - - - - - - - - - - - Tag Type - - - - - - - - - - - - - //
 
 type tagType string
@@ -191,9 +236,20 @@ const (
 
 	// TagTypeRemove will remove that node in the _PreRender_ phase with a high priority.
 	TagTypeRemove tagType = "remove"
+
+	// TagTypeKeep will keep that node as html (e.g. "<sup>2</sup>") instead of
+	// converting it to markdown. It takes precedence over the registered renderers.
+	//
+	// The html is sanitized, see `WithKeepAllowlist`.
+	TagTypeKeep tagType = "keep"
 )
 
 func (r *register) TagType(tagName string, tagType tagType, priority int) {
+	if _, remove := keepRemovedElements[tagName]; remove && tagType == TagTypeKeep {
+		r.conv.setError(fmt.Errorf("the element %q can not be kept as html", tagName))
+		return
+	}
+
 	r.conv.m.Lock()
 	defer r.conv.m.Unlock()
 
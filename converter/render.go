@@ -7,6 +7,10 @@ import (
 
 func (conv *Converter) handleRenderNodes(ctx Context, w Writer, nodes ...*html.Node) {
 	for _, node := range nodes {
+		if ctx.Err() != nil {
+			// The context was canceled, so there is no point in continuing.
+			return
+		}
 		conv.handleRenderNode(ctx, w, node)
 	}
 }
@@ -19,7 +23,12 @@ func (conv *Converter) handleRenderNode(ctx Context, w Writer, node *html.Node)
 		return conv.handleRenderText(ctx, w, node)
 	}
 
-	// - - B: the render handlers - - //
+	// - - B: the elements that are kept as html - - //
+	if tagType, _ := ctx.GetTagType(name); tagType == TagTypeKeep {
+		return conv.handleRenderKeep(ctx, w, node)
+	}
+
+	// - - C: the render handlers - - //
 	for _, handler := range conv.getRenderHandlers() {
 		status := handler.Value(ctx, w, node)
 		if status == RenderSuccess {
@@ -27,7 +36,7 @@ func (conv *Converter) handleRenderNode(ctx Context, w Writer, node *html.Node)
 		}
 	}
 
-	// - - C: the fallback - - //
+	// - - D: the fallback - - //
 	// If nothing works we fallback to this:
 	return conv.handleRenderFallback(ctx, w, node)
 }
@@ -1,8 +1,13 @@
 package converter
 
 import (
+	"io"
 	"net/url"
 	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/domutils"
+	"golang.org/x/net/html"
 )
 
 var percentEncodingReplacer = strings.NewReplacer(
@@ -34,6 +39,275 @@ func parseBaseDomain(rawDomain string) *url.URL {
 
 	return nil
 }
+
+// findBaseHref returns the href of the first <base> element with a
+// href attribute. Like in the browser, all the other <base> elements are ignored.
+func findBaseHref(doc *html.Node) (string, bool) {
+	baseNode := dom.FindFirstNode(doc, func(n *html.Node) bool {
+		if dom.NodeName(n) != "base" {
+			return false
+		}
+		_, hasHref := dom.GetAttribute(n, "href")
+		return hasHref
+	})
+	if baseNode == nil {
+		return "", false
+	}
+
+	href := dom.GetAttributeOr(baseNode, "href", "")
+	return strings.TrimSpace(href), true
+}
+
+// resolveBaseDomain combines the `domain` (from WithDomain) with the
+// <base href> of the document. Similar to the HTML spec, the domain takes
+// the role of the document's url:
+//
+//   - An absolute base href is used instead of the domain.
+//   - A relative base href is resolved against the domain.
+//   - Without a domain, only a base href starting with "/" can be used.
+//   - A base href with the "data:" or "javascript:" scheme is ignored.
+//
+// Only a base href can result in a domain that is just a path (e.g. "/docs/v2/").
+// A domain from WithDomain without a host is ignored, like it always was.
+func resolveBaseDomain(doc *html.Node, domain string) string {
+	if parseBaseDomain(domain) == nil {
+		domain = ""
+	}
+
+	href, ok := findBaseHref(doc)
+	if !ok || href == "" {
+		return domain
+	}
+
+	baseURL, err := url.Parse(href)
+	if err != nil {
+		return domain
+	}
+	if baseURL.Scheme == "data" || baseURL.Scheme == "javascript" {
+		return domain
+	}
+	if baseURL.IsAbs() {
+		return baseURL.String()
+	}
+
+	if documentURL := parseBaseDomain(domain); documentURL != nil {
+		return documentURL.ResolveReference(baseURL).String()
+	}
+
+	if strings.HasPrefix(href, "/") {
+		return href
+	}
+	return domain
+}
+
+// - - - - - - - - - - URL Schemes - - - - - - - - - - //
+
+// DefaultAllowedURLSchemes are the schemes that are considered safe.
+// The special value "data:image" allows data uris with an image
+// mime type (e.g. "data:image/png;base64,...").
+var DefaultAllowedURLSchemes = []string{"http", "https", "mailto", "tel", "ftp", "data:image"}
+
+type disallowedURLBehavior string
+
+const (
+	// DisallowedURLBehaviorStrip removes the url. For a link only the text is kept
+	// and an image is removed completely.
+	DisallowedURLBehaviorStrip disallowedURLBehavior = "strip"
+	// DisallowedURLBehaviorNeutralize replaces the url with "#".
+	DisallowedURLBehaviorNeutralize disallowedURLBehavior = "neutralize"
+)
+
+// urlAttributes are the attributes of (raw) html that contain urls.
+var urlAttributes = map[string]struct{}{
+	"href": {}, "src": {}, "cite": {}, "poster": {}, "action": {}, "formaction": {},
+	"background": {}, "data": {}, "longdesc": {}, "srcset": {},
+}
+
+// parseURLScheme returns the lowercase scheme (e.g. "javascript") or
+// an empty string for a relative url. It mirrors what a browser (and the
+// markdown renderer) does: character references are decoded and
+// tabs & newlines are ignored. That way "java&#x09;script:" is also detected.
+func parseURLScheme(rawURL string) string {
+	rawURL = html.UnescapeString(rawURL)
+	rawURL = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, rawURL)
+	rawURL = strings.TrimLeftFunc(rawURL, func(r rune) bool {
+		return r <= ' '
+	})
+
+	index := strings.IndexAny(rawURL, ":/?#")
+	if index <= 0 || rawURL[index] != ':' {
+		return ""
+	}
+
+	scheme := strings.ToLower(rawURL[:index])
+	for i, r := range scheme {
+		isLetter := r >= 'a' && r <= 'z'
+		isOther := (r >= '0' && r <= '9') || r == '+' || r == '-' || r == '.'
+		if !isLetter && (i == 0 || !isOther) {
+			// Not a valid scheme, so the browser treats it as a relative url.
+			return ""
+		}
+	}
+	return scheme
+}
+
+// isAllowedURL checks the scheme of the url against the allowlist.
+// Relative urls are always allowed.
+func isAllowedURL(rawURL string, allowedSchemes []string) bool {
+	scheme := parseURLScheme(rawURL)
+	if scheme == "" {
+		return true
+	}
+
+	for _, allowed := range allowedSchemes {
+		allowed = strings.ToLower(allowed)
+		if allowed == scheme {
+			return true
+		}
+
+		if allowed == "data:image" && scheme == "data" {
+			// Only the mime type until the first "," or ";" is compared.
+			data := strings.TrimSpace(html.UnescapeString(rawURL))
+			mimeType, _, _ := strings.Cut(data[len("data:"):], ",")
+			mimeType, _, _ = strings.Cut(mimeType, ";")
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(mimeType)), "image/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getAllowedURLSchemes returns the allowlist from `WithAllowedURLSchemes`.
+// By default every scheme is allowed (nil), unless `strict` is true
+// where the `DefaultAllowedURLSchemes` are used instead.
+func (conv *Converter) getAllowedURLSchemes(strict bool) []string {
+	conv.m.RLock()
+	defer conv.m.RUnlock()
+
+	if conv.allowedURLSchemes == nil && strict {
+		return DefaultAllowedURLSchemes
+	}
+	return conv.allowedURLSchemes
+}
+func (conv *Converter) getDisallowedURLBehavior() disallowedURLBehavior {
+	conv.m.RLock()
+	defer conv.m.RUnlock()
+
+	if conv.disallowedURLBehavior == "" {
+		return DisallowedURLBehaviorStrip
+	}
+	return conv.disallowedURLBehavior
+}
+
+// checkURL applies the allowlist of url schemes. If the bool is false
+// the url (and depending on the renderer the element) should be dropped.
+func (conv *Converter) checkURL(rawURL string, strict bool) (string, bool) {
+	allowedSchemes := conv.getAllowedURLSchemes(strict)
+	if allowedSchemes == nil || isAllowedURL(rawURL, allowedSchemes) {
+		return rawURL, true
+	}
+
+	if conv.getDisallowedURLBehavior() == DisallowedURLBehaviorNeutralize {
+		return "#", true
+	}
+	return "", false
+}
+
+// isAllowedSrcset checks every url in a srcset attribute.
+func (conv *Converter) isAllowedSrcset(srcset string, strict bool) bool {
+	allowedSchemes := conv.getAllowedURLSchemes(strict)
+	if allowedSchemes == nil {
+		return true
+	}
+
+	for _, candidate := range domutils.ParseSrcset(srcset) {
+		if !isAllowedURL(candidate.URL, allowedSchemes) {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeURLAttributes returns a copy of the attributes where
+// the urls are checked with `checkURL`. Event handlers (e.g. "onerror")
+// can run scripts just like a "javascript:" url, so they are removed.
+func (conv *Converter) sanitizeURLAttributes(attrs []html.Attribute, strict bool) []html.Attribute {
+	sanitized := make([]html.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if isEventHandler(key) {
+			continue
+		}
+		if _, isURL := urlAttributes[key]; isURL || (attr.Namespace == "xlink" && key == "href") {
+			if key == "srcset" {
+				if !conv.isAllowedSrcset(attr.Val, strict) {
+					// A srcset can not be neutralized, so it is always removed
+					continue
+				}
+			} else {
+				val, keep := conv.checkURL(attr.Val, strict)
+				if !keep {
+					continue
+				}
+				attr.Val = val
+			}
+		}
+		sanitized = append(sanitized, attr)
+	}
+	return sanitized
+}
+
+// isEventHandler reports whether the attribute (e.g. "onerror")
+// can run a script.
+func isEventHandler(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), "on")
+}
+
+func removeEventHandlers(attrs []html.Attribute) []html.Attribute {
+	kept := make([]html.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		if !isEventHandler(attr.Key) {
+			kept = append(kept, attr)
+		}
+	}
+	return kept
+}
+
+// renderHTML renders the node (including the children) as html while
+// applying the allowlist of `WithAllowedURLSchemes` to all the urls
+// and removing the event handler attributes.
+func (conv *Converter) renderHTML(w io.Writer, n *html.Node) error {
+	if conv.getAllowedURLSchemes(false) == nil {
+		return html.Render(w, cloneWithAttributes(n, removeEventHandlers))
+	}
+
+	return html.Render(w, cloneWithAttributes(n, func(attrs []html.Attribute) []html.Attribute {
+		return conv.sanitizeURLAttributes(attrs, false)
+	}))
+}
+
+// cloneWithAttributes returns a deep copy of the node
+// where the attributes are replaced by the result of `fn`.
+func cloneWithAttributes(n *html.Node, fn func(attrs []html.Attribute) []html.Attribute) *html.Node {
+	clone := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+		Attr:      fn(n.Attr),
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		clone.AppendChild(cloneWithAttributes(child, fn))
+	}
+	return clone
+}
+
 func defaultAssembleAbsoluteURL(tagName string, rawURL string, domain string) string {
 	rawURL = strings.TrimSpace(rawURL)
 
@@ -71,6 +345,10 @@ func defaultAssembleAbsoluteURL(tagName string, rawURL string, domain string) st
 		// If a "domain" is provided, we use that to convert relative links
 		// to absolute links.
 		u = base.ResolveReference(u)
+	} else if base, err := url.Parse(domain); err == nil && strings.HasPrefix(domain, "/") {
+		// Only a path (e.g. "/docs/v2/" from a <base href>) without a host.
+		// Relative urls are still resolved against this path.
+		u = base.ResolveReference(u)
 	}
 
 	return percentEncodingReplacer.Replace(u.String())
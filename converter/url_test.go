@@ -1,7 +1,10 @@
 package converter
 
 import (
+	"strings"
 	"testing"
+
+	"golang.org/x/net/html"
 )
 
 func TestDefaultAssembleAbsoluteURL(t *testing.T) {
@@ -93,6 +96,15 @@ func TestDefaultAssembleAbsoluteURL(t *testing.T) {
 
 			expected: "https://test.com/page.html?key=val#hash",
 		},
+		{
+			desc: "with domain that is only a path",
+
+			tagName: "a",
+			input:   "page.html",
+			domain:  "/docs/v2/",
+
+			expected: "/docs/v2/page.html",
+		},
 
 		{
 			desc: "data uri",
@@ -251,6 +263,157 @@ func TestDefaultAssembleAbsoluteURL(t *testing.T) {
 	}
 }
 
+func TestResolveBaseDomain(t *testing.T) {
+	runs := []struct {
+		desc string
+
+		input  string
+		domain string
+
+		expected string
+	}{
+		{
+			desc:   "no base element",
+			input:  `<a href="page.html">link</a>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://test.com/blog/",
+		},
+		{
+			desc:   "no base element and no domain",
+			input:  `<a href="page.html">link</a>`,
+			domain: "",
+
+			expected: "",
+		},
+		{
+			desc:   "base without href",
+			input:  `<head><base target="_blank"></head>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://test.com/blog/",
+		},
+		{
+			desc:   "base with empty href",
+			input:  `<head><base href=""></head>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://test.com/blog/",
+		},
+		{
+			desc:   "absolute base replaces the domain",
+			input:  `<head><base href="https://other.com/docs/"></head>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://other.com/docs/",
+		},
+		{
+			desc:   "absolute base without a domain",
+			input:  `<head><base href="https://other.com/docs/"></head>`,
+			domain: "",
+
+			expected: "https://other.com/docs/",
+		},
+		{
+			desc:   "path is resolved against the domain",
+			input:  `<head><base href="/docs/v2/"></head>`,
+			domain: "https://test.com/blog/article.html",
+
+			expected: "https://test.com/docs/v2/",
+		},
+		{
+			desc:   "relative path is resolved against the domain",
+			input:  `<head><base href="../docs/"></head>`,
+			domain: "https://test.com/blog/article.html",
+
+			expected: "https://test.com/docs/",
+		},
+		{
+			desc:   "domain without scheme",
+			input:  `<head><base href="/docs/"></head>`,
+			domain: "test.com",
+
+			expected: "http://test.com/docs/",
+		},
+		{
+			desc:   "protocol relative base takes the scheme of the domain",
+			input:  `<head><base href="//cdn.test.com/assets/"></head>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://cdn.test.com/assets/",
+		},
+		{
+			desc:   "path without a domain",
+			input:  `<head><base href="/docs/v2/"></head>`,
+			domain: "",
+
+			expected: "/docs/v2/",
+		},
+		{
+			desc:   "domain that is only a path is ignored",
+			input:  `<a href="page.html">link</a>`,
+			domain: "/docs/",
+
+			expected: "",
+		},
+		{
+			desc:   "path with a domain that is only a path",
+			input:  `<head><base href="/docs/v2/"></head>`,
+			domain: "/blog/",
+
+			expected: "/docs/v2/",
+		},
+		{
+			desc:   "relative path without a domain is ignored",
+			input:  `<head><base href="docs/"></head>`,
+			domain: "",
+
+			expected: "",
+		},
+		{
+			desc:   "only the first base with href counts",
+			input:  `<head><base target="_top"><base href="/first/"><base href="/second/"></head>`,
+			domain: "https://test.com",
+
+			expected: "https://test.com/first/",
+		},
+		{
+			desc:   "base inside the body",
+			input:  `<body><base href="/docs/"><a href="page.html">link</a></body>`,
+			domain: "https://test.com",
+
+			expected: "https://test.com/docs/",
+		},
+		{
+			desc:   "javascript base is ignored",
+			input:  `<head><base href="javascript:alert(1)"></head>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://test.com/blog/",
+		},
+		{
+			desc:   "data base is ignored",
+			input:  `<head><base href="data:text/html,abc"></head>`,
+			domain: "https://test.com/blog/",
+
+			expected: "https://test.com/blog/",
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(run.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res := resolveBaseDomain(doc, run.domain)
+			if res != run.expected {
+				t.Errorf("expected '%s' but got '%s'", run.expected, res)
+			}
+		})
+	}
+}
+
 func TestParseAndEncodeQuery(t *testing.T) {
 	runs := []struct {
 		desc string
@@ -331,3 +494,47 @@ func TestParseAndEncodeQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAllowedURL(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "", expected: true},
+		{input: "/page.html", expected: true},
+		{input: "page.html?a=b:c", expected: true},
+		{input: "#heading", expected: true},
+		{input: "//example.com", expected: true},
+		{input: "https://example.com", expected: true},
+		{input: "HTTPS://example.com", expected: true},
+		{input: "mailto:hi@example.com", expected: true},
+
+		{input: "javascript:alert(1)", expected: false},
+		{input: "JavaScript:alert(1)", expected: false},
+		{input: "  javascript:alert(1)", expected: false},
+		{input: "java\tscript:alert(1)", expected: false},
+		{input: "java\nscript:alert(1)", expected: false},
+		{input: "&#106;avascript:alert(1)", expected: false},
+		{input: "javascript&colon;alert(1)", expected: false},
+		{input: "vbscript:msgbox(1)", expected: false},
+		{input: "file:///etc/passwd", expected: false},
+
+		// Not a valid scheme, so the browser treats it as a relative url
+		{input: "java%09script:alert(1)", expected: true},
+		{input: "1http:abc", expected: true},
+
+		{input: "data:image/png;base64,iVBORw0KGgo=", expected: true},
+		{input: "DATA:IMAGE/GIF,abc", expected: true},
+		{input: "data:text/html;base64,PHNjcmlwdD4=", expected: false},
+		{input: "data:text/html,<b>image/</b>", expected: false},
+		{input: "data:,image/png", expected: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			output := isAllowedURL(tC.input, DefaultAllowedURLSchemes)
+			if output != tC.expected {
+				t.Errorf("expected %v but got %v", tC.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package domutils
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type SrcsetCandidate struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// ParseSrcset parses the "srcset" attribute, e.g. "/a.png 480w, /b.png 960w".
+// The urls can contain commas (e.g. data uris), so we can not just split by ",".
+func ParseSrcset(srcset string) []SrcsetCandidate {
+	var candidates []SrcsetCandidate
+
+	i := 0
+	for i < len(srcset) {
+		// Skip the separators between the candidates
+		for i < len(srcset) && (isSpace(srcset[i]) || srcset[i] == ',') {
+			i++
+		}
+		if i >= len(srcset) {
+			break
+		}
+
+		start := i
+		for i < len(srcset) && !isSpace(srcset[i]) {
+			i++
+		}
+		url := srcset[start:i]
+
+		var descriptor string
+		if strings.HasSuffix(url, ",") {
+			// A candidate without descriptor
+			url = strings.TrimRight(url, ",")
+		} else {
+			start = i
+			for i < len(srcset) && srcset[i] != ',' {
+				i++
+			}
+			descriptor = strings.TrimSpace(srcset[start:i])
+		}
+		if url == "" {
+			continue
+		}
+
+		candidate := SrcsetCandidate{
+			URL:     url,
+			Density: 1,
+		}
+		if w, ok := strings.CutSuffix(descriptor, "w"); ok {
+			if width, err := strconv.Atoi(w); err == nil && width > 0 {
+				candidate.Width = width
+			}
+		} else if x, ok := strings.CutSuffix(descriptor, "x"); ok {
+			if density, err := strconv.ParseFloat(x, 64); err == nil && density > 0 {
+				candidate.Density = density
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+func isSpace(b byte) bool {
+	return unicode.IsSpace(rune(b))
+}
@@ -0,0 +1,59 @@
+package domutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSrcset(t *testing.T) {
+	runs := []struct {
+		desc     string
+		input    string
+		expected []SrcsetCandidate
+	}{
+		{
+			desc:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			desc:  "width descriptors",
+			input: "/a.png 480w, /b.png 960w",
+			expected: []SrcsetCandidate{
+				{URL: "/a.png", Width: 480, Density: 1},
+				{URL: "/b.png", Width: 960, Density: 1},
+			},
+		},
+		{
+			desc:  "comma without whitespace",
+			input: "a.png 1x,javascript:alert(1) 2x",
+			expected: []SrcsetCandidate{
+				{URL: "a.png", Density: 1},
+				{URL: "javascript:alert(1)", Density: 2},
+			},
+		},
+		{
+			desc:  "without descriptor",
+			input: "/a.png, /b.png 2x",
+			expected: []SrcsetCandidate{
+				{URL: "/a.png", Density: 1},
+				{URL: "/b.png", Density: 2},
+			},
+		},
+		{
+			desc:  "comma inside of the url",
+			input: "data:image/png;base64,iVBORw0KGgo= 1x",
+			expected: []SrcsetCandidate{
+				{URL: "data:image/png;base64,iVBORw0KGgo=", Density: 1},
+			},
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			output := ParseSrcset(run.input)
+			if !reflect.DeepEqual(output, run.expected) {
+				t.Errorf("expected %+v but got %+v", run.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package escape
+
+// IsAutolinkLiteral detects the autolink literals of GitHub Flavored Markdown.
+// They are links, even without any special syntax.
+func IsAutolinkLiteral(chars []byte, index int) int {
+	if chars[index] == ':' {
+		return isAutolinkLiteralScheme(chars, index)
+	}
+	if chars[index] == '.' {
+		return isAutolinkLiteralWWW(chars, index)
+	}
+	if chars[index] == '@' {
+		return isAutolinkLiteralEmail(chars, index)
+	}
+
+	return -1
+}
+
+func isAlphaNumeric(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || IsDigit(b)
+}
+
+// isAutolinkBoundary checks the character before an autolink literal.
+// They can only come at the beginning of a line, after whitespace
+// or any of the delimiting characters "*", "_", "~" and "(".
+func isAutolinkBoundary(b byte) bool {
+	return b == 0 || IsSpace(b) || b == '*' || b == '_' || b == '~' || b == '('
+}
+
+// getPrevWord returns the characters (without the placeholders)
+// before the index that match the function and the character before them.
+func getPrevWord(chars []byte, index int, fn func(b byte) bool) (word []byte, before byte) {
+	for i := index - 1; i >= 0; i-- {
+		if chars[i] == placeholderByte {
+			continue
+		}
+		if !fn(chars[i]) {
+			before = chars[i]
+			break
+		}
+		word = append([]byte{chars[i]}, word...)
+	}
+	return word, before
+}
+
+// getNextBytes returns up to n characters after the index, without the placeholders.
+func getNextBytes(chars []byte, index int, n int) []byte {
+	var next []byte
+	for i := index + 1; i < len(chars) && len(next) < n; i++ {
+		if chars[i] == placeholderByte {
+			continue
+		}
+		next = append(next, chars[i])
+	}
+	return next
+}
+
+// isValidDomainStart checks whether the characters could be the
+// start of a valid domain, e.g. "example.com" but not "example" or ".com"
+func isValidDomainStart(chars []byte, index int) bool {
+	hasSegment := false
+	for i := index + 1; i < len(chars); i++ {
+		if chars[i] == placeholderByte {
+			continue
+		}
+
+		switch {
+		case isAlphaNumeric(chars[i]) || chars[i] == '-' || chars[i] == '_' || chars[i] >= 0x80:
+			hasSegment = true
+		case chars[i] == '.' && hasSegment:
+			next := getNext(chars, i)
+			return isAlphaNumeric(next) || next >= 0x80
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func isAutolinkLiteralScheme(chars []byte, index int) int {
+	scheme, before := getPrevWord(chars, index, isAlphaNumeric)
+	if string(scheme) != "http" && string(scheme) != "https" {
+		return -1
+	}
+	if !isAutolinkBoundary(before) {
+		return -1
+	}
+
+	if string(getNextBytes(chars, index, 2)) != "//" {
+		return -1
+	}
+	next := getNextBytes(chars, index, 3)
+	if len(next) < 3 || !(isAlphaNumeric(next[2]) || next[2] >= 0x80) {
+		return -1
+	}
+
+	// e.g. "https://example.com"
+	return 1
+}
+
+func isAutolinkLiteralWWW(chars []byte, index int) int {
+	word, before := getPrevWord(chars, index, isAlphaNumeric)
+	if string(word) != "www" || !isAutolinkBoundary(before) {
+		return -1
+	}
+
+	next := getNext(chars, index)
+	if !isAlphaNumeric(next) && next < 0x80 {
+		return -1
+	}
+
+	// e.g. "www.example.com"
+	return 1
+}
+
+func isAutolinkLiteralEmail(chars []byte, index int) int {
+	local, _ := getPrevWord(chars, index, func(b byte) bool {
+		return isAlphaNumeric(b) || b == '.' || b == '_' || b == '+' || b == '-'
+	})
+	if len(local) == 0 {
+		return -1
+	}
+
+	if !isValidDomainStart(chars, index) {
+		return -1
+	}
+
+	// e.g. "hello@example.com"
+	return 1
+}
@@ -0,0 +1,75 @@
+package escape
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsAutolinkLiteral(t *testing.T) {
+	runs := []struct {
+		name  string
+		chars []byte
+
+		expected []int
+	}{
+		{
+			name:     "autolink literal with scheme",
+			chars:    []byte{'h', 't', 't', 'p', 's', placeholderByte, ':', '/', '/', 'a', '.', 'b'},
+			expected: []int{-1, -1, -1, -1, -1, -1, 1, -1, -1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal with other scheme",
+			chars:    []byte{'f', 't', 'p', placeholderByte, ':', '/', '/', 'a'},
+			expected: []int{-1, -1, -1, -1, -1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal within a word",
+			chars:    []byte{'a', 'h', 't', 't', 'p', placeholderByte, ':', '/', '/', 'a'},
+			expected: []int{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal without domain",
+			chars:    []byte{'h', 't', 't', 'p', placeholderByte, ':', '/', '/', ' '},
+			expected: []int{-1, -1, -1, -1, -1, -1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal with www",
+			chars:    []byte{'(', 'w', 'w', 'w', placeholderByte, '.', 'a', '.', 'b'},
+			expected: []int{-1, -1, -1, -1, -1, 1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal with www at end of sentence",
+			chars:    []byte{'w', 'w', 'w', placeholderByte, '.'},
+			expected: []int{-1, -1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal with email",
+			chars:    []byte{'a', '.', 'b', placeholderByte, '@', 'c', '.', 'd'},
+			expected: []int{-1, -1, -1, -1, 1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal with email without dot",
+			chars:    []byte{'a', placeholderByte, '@', 'c', 'd'},
+			expected: []int{-1, -1, -1, -1, -1},
+		},
+		{
+			name:     "autolink literal with mention",
+			chars:    []byte{' ', placeholderByte, '@', 'c', '.', 'd'},
+			expected: []int{-1, -1, -1, -1, -1, -1},
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.name, func(t *testing.T) {
+			var actual []int
+			for index := range run.chars {
+				output := IsAutolinkLiteral(run.chars, index)
+
+				actual = append(actual, output)
+			}
+
+			if !reflect.DeepEqual(actual, run.expected) {
+				t.Errorf("expected %+v but got %+v", run.expected, actual)
+			}
+		})
+	}
+}
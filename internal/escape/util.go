@@ -68,3 +68,7 @@ func getNextAsRune(source []byte, index int) rune {
 func GetNextAsRune(source []byte, index int) rune {
 	return getNextAsRune(source, index)
 }
+
+func GetPrevAsRune(source []byte, index int) rune {
+	return getPrevAsRune(source, index)
+}
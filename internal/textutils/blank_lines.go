@@ -0,0 +1,25 @@
+package textutils
+
+import "strings"
+
+// EncodeBlankLines replaces the newline after a blank line with the
+// character reference "&#10;". A blank line would end an html block
+// but the content (e.g. inside of a <pre>) should stay the same.
+func EncodeBlankLines(content string) string {
+	lines := strings.Split(content, "\n")
+
+	var buf strings.Builder
+	for i, line := range lines {
+		buf.WriteString(line)
+		if i == len(lines)-1 {
+			break
+		}
+
+		if strings.TrimSpace(line) == "" {
+			buf.WriteString("&#10;")
+		} else {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,40 @@
+package textutils
+
+import "testing"
+
+func TestEncodeBlankLines(t *testing.T) {
+	runs := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "empty",
+			input:    "",
+			expected: "",
+		},
+		{
+			desc:     "without blank lines",
+			input:    "<div>\na\n</div>",
+			expected: "<div>\na\n</div>",
+		},
+		{
+			desc:     "blank line",
+			input:    "<pre>a\n\nb</pre>",
+			expected: "<pre>a\n&#10;b</pre>",
+		},
+		{
+			desc:     "line with only spaces",
+			input:    "<pre>a\n  \nb</pre>",
+			expected: "<pre>a\n  &#10;b</pre>",
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			output := EncodeBlankLines(run.input)
+			if output != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, output)
+			}
+		})
+	}
+}
@@ -89,6 +89,10 @@ func (b *base) preRenderCollapse(ctx converter.Context, doc *html.Node) {
 		IsBlockNode: func(node *html.Node) bool {
 			tagName := dom.NodeName(node)
 			tagType, ok := ctx.GetTagType(tagName)
+			if ok && tagType == converter.TagTypeKeep {
+				// The html is kept, but it can still be a block element.
+				return dom.NameIsBlockNode(tagName)
+			}
 			if ok {
 				return tagType == converter.TagTypeBlock
 			}
@@ -6,7 +6,7 @@ import (
 	"golang.org/x/net/html"
 )
 
-// RenderAsHTML will render the node as HTML using `html.Render()`
+// RenderAsHTML will render the node as HTML using `converter.RenderHTML()`
 // Newlines will be inserted depending on the configured `TagType`.
 //
 // As an example, you could do such a combination:
@@ -19,7 +19,7 @@ func RenderAsHTML(ctx converter.Context, w converter.Writer, node *html.Node) co
 	if tagType == converter.TagTypeBlock {
 		w.WriteString("\n\n")
 	}
-	_ = html.Render(w, node) // TODO: what to do with error?
+	_ = converter.RenderHTML(ctx, w, node) // TODO: what to do with error?
 	if tagType == converter.TagTypeBlock {
 		w.WriteString("\n\n")
 	}
@@ -104,6 +104,61 @@ func WithLinkEmptyContentBehavior(behavior linkRenderingBehavior) OptionFunc {
 	}
 }
 
+// WithImageSourceStrategy configures which url is used for an image
+// that has multiple sources (e.g. with "srcset" or inside a <picture>).
+//
+// "src", "largest", "width" or "type"
+//
+// default: "src"
+func WithImageSourceStrategy(strategy imageSourceStrategy) OptionFunc {
+	return func(config *config) {
+		config.ImageSourceStrategy = strategy
+	}
+}
+
+// WithImageSourceWidth configures the preferred width
+// for the ImageSourceStrategyWidth.
+func WithImageSourceWidth(width int) OptionFunc {
+	return func(config *config) {
+		config.ImageSourceWidth = width
+	}
+}
+
+// WithImageSourceType configures the preferred type (e.g. "image/webp")
+// for the ImageSourceStrategyType.
+func WithImageSourceType(mimeType string) OptionFunc {
+	return func(config *config) {
+		config.ImageSourceType = mimeType
+	}
+}
+
+// WithImageFallbackAttributes configures the attributes that are used if the "src"
+// of an image is empty or a placeholder (e.g. a 1x1 gif of a lazy loading library).
+// Pass no attributes to disable the fallback.
+//
+// default: "data-src", "data-lazy-src", "data-original"
+func WithImageFallbackAttributes(attributes ...string) OptionFunc {
+	return func(config *config) {
+		config.ImageFallbackAttributes = append([]string{}, attributes...)
+	}
+}
+
+// WithAutolinkStyle configures how links are rendered whose
+// content is the same as the href (or the email address of a "mailto:" link).
+//
+// "none" would result in "[https://example.com](https://example.com)"
+//
+// "angle_brackets" would result in "<https://example.com>"
+//
+// "literal" would result in "https://example.com" (GitHub Flavored Markdown)
+//
+// default: "none"
+func WithAutolinkStyle(style autolinkStyle) OptionFunc {
+	return func(config *config) {
+		config.AutolinkStyle = style
+	}
+}
+
 // TODO: allow changing the link style once the render logic is implemented
 //
 // "inlined" or "referenced_index" or "referenced_short"
@@ -174,6 +229,13 @@ func (cm *commonmark) Init(conv *converter.Converter) error {
 	conv.Register.UnEscaper(escape.IsInlineCode, converter.PriorityStandard)
 	conv.Register.UnEscaper(escape.IsBackslash, converter.PriorityStandard)
 
+	if cm.AutolinkStyle == AutolinkStyleLiteral {
+		// Only with GitHub Flavored Markdown would a url in
+		// the text become a link, so only then does it need escaping.
+		conv.Register.EscapedChar(':', '@')
+		conv.Register.UnEscaper(escape.IsAutolinkLiteral, converter.PriorityStandard)
+	}
+
 	conv.Register.Renderer(cm.handleRender, converter.PriorityStandard)
 
 	conv.Register.TextTransformer(cm.handleTextTransform, converter.PriorityLate)
@@ -195,6 +257,12 @@ func (cm commonmark) handleTextTransform(ctx converter.Context, content string)
 
 	if isEnabled, ok := ctx.Value("is_inside_link").(bool); ok && isEnabled {
 		content = strings.Replace(content, string(marker.MarkerEscaping)+`]`, `\]`, -1)
+
+		// There are no autolink literals inside of links,
+		// so "https://" does not need to be escaped there.
+		content = strings.Replace(content, string(marker.MarkerEscaping)+`:`, `:`, -1)
+		content = strings.Replace(content, string(marker.MarkerEscaping)+`@`, `@`, -1)
+		content = strings.Replace(content, `www`+string(marker.MarkerEscaping)+`.`, `www.`, -1)
 	}
 	// if isEnabled, ok := ctx.Value("is_inside_heading").(bool); ok && isEnabled {
 	// 	// The "#" character would be completely removed, if at the _end_
@@ -189,6 +189,170 @@ func TestOptionFunc(t *testing.T) {
 			input:    `<a href="/page"></a>`,
 			expected: "",
 		},
+		// - - - //
+		{
+			desc: "WithAutolinkStyle(none)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleNone),
+			},
+			input:    `<p><a href="https://example.com">https://example.com</a> and <a href="mailto:hi@example.com">hi@example.com</a></p>`,
+			expected: "[https://example.com](https://example.com) and [hi@example.com](mailto:hi@example.com)",
+		},
+		{
+			desc: "WithAutolinkStyle(angle_brackets)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleAngleBrackets),
+			},
+			input:    `<p><a href="https://example.com/a_b">https://example.com/a_b</a> and <a href="mailto:hi@example.com">hi@example.com</a></p>`,
+			expected: "<https://example.com/a_b> and <hi@example.com>",
+		},
+		{
+			desc: "WithAutolinkStyle(angle_brackets) with different content",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleAngleBrackets),
+			},
+			input:    `<p><a href="/page">https://example.com</a> <a href="https://example.com" title="Title">https://example.com</a> <a href="https://example.com"><b>https://example.com</b></a></p>`,
+			expected: `[https://example.com](/page) [https://example.com](https://example.com "Title") [**https://example.com**](https://example.com)`,
+		},
+		{
+			desc: "WithAutolinkStyle(angle_brackets) with www",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleAngleBrackets),
+			},
+			input:    `<p><a href="http://www.example.com">www.example.com</a></p>`,
+			expected: "[www.example.com](http://www.example.com)",
+		},
+		{
+			desc: "WithAutolinkStyle(literal)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleLiteral),
+			},
+			input:    `<p>See <a href="https://example.com/a_b">https://example.com/a_b</a>, <a href="http://www.example.com">www.example.com</a> or <a href="mailto:hi@example.com">hi@example.com</a>.</p>`,
+			expected: "See https://example.com/a_b, www.example.com or hi@example.com.",
+		},
+		{
+			desc: "WithAutolinkStyle(literal) falls back to angle brackets",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleLiteral),
+			},
+			input:    `<p>a<a href="https://example.com">https://example.com</a>b <a href="https://example.com/.">https://example.com/.</a> <a href="ftp://example.com">ftp://example.com</a></p>`,
+			expected: "a<https://example.com>b <https://example.com/.> <ftp://example.com>",
+		},
+		{
+			desc:     "no escaping of autolink literals by default",
+			input:    `<p>Visit https://example.com, www.example.com or hi@example.com</p>`,
+			expected: `Visit https://example.com, www.example.com or hi@example.com`,
+		},
+		{
+			desc: "escape autolink literals",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleLiteral),
+			},
+			input:    `<p>Visit https://example.com, www.example.com or hi@example.com</p>`,
+			expected: `Visit https\://example.com, www\.example.com or hi\@example.com`,
+		},
+		{
+			desc: "no escaping of autolink literals inside links",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle(commonmark.AutolinkStyleLiteral),
+			},
+			input:    `<p><a href="/page">Visit https://example.com or hi@example.com</a> and @user or http://</p>`,
+			expected: `[Visit https://example.com or hi@example.com](/page) and @user or http://`,
+		},
+
+		// - - - - - - - - - - Image - - - - - - - - - - //
+		{
+			desc: "WithImageSourceStrategy(src)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategySrc),
+			},
+			input:    `<img src="/small.jpg" srcset="/medium.jpg 800w, /large.jpg 1600w" />`,
+			expected: "![](/small.jpg)",
+		},
+		{
+			desc: "WithImageSourceStrategy(largest)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyLargest),
+			},
+			input:    `<img src="/small.jpg" srcset="/large.jpg 1600w, /medium.jpg 800w" />`,
+			expected: "![](/large.jpg)",
+		},
+		{
+			desc: "WithImageSourceStrategy(largest) with density",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyLargest),
+			},
+			input:    `<img src="/small.jpg" srcset="/image.jpg, /image-2x.jpg 2x, /image-1.5x.jpg 1.5x" />`,
+			expected: "![](/image-2x.jpg)",
+		},
+		{
+			desc: "WithImageSourceStrategy(largest) with picture",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyLargest),
+			},
+			input:    `<picture><source srcset="/large.webp 1600w" type="image/webp" /><img src="/small.jpg" srcset="/medium.jpg 800w" /></picture>`,
+			expected: "![](/large.webp)",
+		},
+		{
+			desc: "WithImageSourceStrategy(largest) with comma in url",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyLargest),
+			},
+			input:    `<img srcset="/image.jpg?size=100,100 100w,/image.jpg?size=900,900 900w" />`,
+			expected: "![](/image.jpg?size=900%2C900)",
+		},
+		{
+			desc: "WithImageSourceStrategy(width)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyWidth),
+				commonmark.WithImageSourceWidth(700),
+			},
+			input:    `<img src="/small.jpg" srcset="/small.jpg 400w, /large.jpg 1600w, /medium.jpg 800w" />`,
+			expected: "![](/medium.jpg)",
+		},
+		{
+			desc: "WithImageSourceStrategy(width) larger than all",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyWidth),
+				commonmark.WithImageSourceWidth(2000),
+			},
+			input:    `<img src="/small.jpg" srcset="/small.jpg 400w, /large.jpg 1600w, /medium.jpg 800w" />`,
+			expected: "![](/large.jpg)",
+		},
+		{
+			desc: "WithImageSourceStrategy(type)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyType),
+				commonmark.WithImageSourceType("image/avif"),
+			},
+			input:    `<picture><source srcset="/image.webp" type="image/webp" /><source srcset="/image.avif 1x, /image-2x.avif 2x" type="image/avif" /><img src="/image.jpg" /></picture>`,
+			expected: "![](/image-2x.avif)",
+		},
+		{
+			desc: "WithImageSourceStrategy(type) without match",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyType),
+				commonmark.WithImageSourceType("image/avif"),
+			},
+			input:    `<picture><source srcset="/image.webp" type="image/webp" /><img src="/image.jpg" /></picture>`,
+			expected: "![](/image.jpg)",
+		},
+		{
+			desc: "WithImageFallbackAttributes(data-lazy)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageFallbackAttributes("data-lazy"),
+			},
+			input:    `<img data-src="/other.jpg" data-lazy="/image.jpg" />`,
+			expected: "![](/image.jpg)",
+		},
+		{
+			desc: "WithImageFallbackAttributes()",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageFallbackAttributes(),
+			},
+			input:    `<img data-src="/image.jpg" />`,
+			expected: "",
+		},
 
 		// TODO: handle other link styles
 		// {
@@ -304,6 +468,35 @@ func TestOptionFunc_ValidationError(t *testing.T) {
 			},
 			expectedError: `error while initializing "commonmark" plugin: invalid value for HeadingStyle:"settext" must be one of "atx" or "setext"`,
 		},
+
+		{
+			desc: "WithImageSourceStrategy(biggest)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy("biggest"),
+			},
+			expectedError: `error while initializing "commonmark" plugin: invalid value for ImageSourceStrategy:"biggest" must be one of "src", "largest", "width" or "type"`,
+		},
+		{
+			desc: "WithImageSourceStrategy(width) without width",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyWidth),
+			},
+			expectedError: `error while initializing "commonmark" plugin: invalid value for ImageSourceWidth:"0" must be greater than zero for the "width" strategy`,
+		},
+		{
+			desc: "WithImageSourceStrategy(type) without type",
+			options: []commonmark.OptionFunc{
+				commonmark.WithImageSourceStrategy(commonmark.ImageSourceStrategyType),
+			},
+			expectedError: `error while initializing "commonmark" plugin: invalid value for ImageSourceType:"" must be a mime type (e.g. "image/webp") for the "type" strategy`,
+		},
+		{
+			desc: "WithAutolinkStyle(bare)",
+			options: []commonmark.OptionFunc{
+				commonmark.WithAutolinkStyle("bare"),
+			},
+			expectedError: `error while initializing "commonmark" plugin: invalid value for AutolinkStyle:"bare" must be one of "none", "angle_brackets" or "literal"`,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -36,6 +36,37 @@ const (
 	LinkBehaviorSkip linkRenderingBehavior = "skip"
 )
 
+type autolinkStyle string
+
+const (
+	// AutolinkStyleNone renders autolinks like any other link, e.g. "[https://example.com](https://example.com)"
+	AutolinkStyleNone autolinkStyle = "none"
+	// AutolinkStyleAngleBrackets renders the autolinks of commonmark, e.g. "<https://example.com>"
+	AutolinkStyleAngleBrackets autolinkStyle = "angle_brackets"
+	// AutolinkStyleLiteral renders the autolink literals of GitHub Flavored Markdown, e.g. "https://example.com"
+	//
+	// If the link would not be recognized (e.g. because of the surrounding text) the angle brackets are used instead.
+	// The urls in the normal text are escaped (e.g. "https\://example.com"), so that they do not turn into links.
+	AutolinkStyleLiteral autolinkStyle = "literal"
+)
+
+type imageSourceStrategy string
+
+const (
+	// ImageSourceStrategySrc uses the "src" attribute. Only if that is missing
+	// (or a placeholder) the largest candidate of the "srcset" is used.
+	ImageSourceStrategySrc imageSourceStrategy = "src"
+	// ImageSourceStrategyLargest uses the largest candidate of the "srcset"
+	// (of the image and the <source> elements inside a <picture>).
+	ImageSourceStrategyLargest imageSourceStrategy = "largest"
+	// ImageSourceStrategyWidth uses the candidate of the "srcset" that is
+	// closest to (but not smaller than) the ImageSourceWidth.
+	ImageSourceStrategyWidth imageSourceStrategy = "width"
+	// ImageSourceStrategyType uses the first <source> inside a <picture>
+	// that matches the ImageSourceType (e.g. "image/webp").
+	ImageSourceStrategyType imageSourceStrategy = "type"
+)
+
 // config to customize the output. You can change stuff like
 // the character that is used for strong text.
 type config struct {
@@ -85,6 +116,24 @@ type config struct {
 
 	LinkEmptyHrefBehavior    linkRenderingBehavior
 	LinkEmptyContentBehavior linkRenderingBehavior
+
+	// "none", "angle_brackets" or "literal"
+	//
+	// default: "none"
+	AutolinkStyle autolinkStyle
+
+	// "src", "largest", "width" or "type"
+	//
+	// default: "src"
+	ImageSourceStrategy imageSourceStrategy
+	ImageSourceWidth    int
+	ImageSourceType     string
+
+	// The attributes that are used if the "src" is empty or a placeholder,
+	// e.g. for lazy loaded images.
+	//
+	// default: "data-src", "data-lazy-src", "data-original"
+	ImageFallbackAttributes []string
 }
 
 func fillInDefaultConfig(cfg *config) config {
@@ -125,6 +174,16 @@ func fillInDefaultConfig(cfg *config) config {
 	if cfg.LinkStyle == "" {
 		cfg.LinkStyle = LinkStyleInlined
 	}
+	if cfg.AutolinkStyle == "" {
+		cfg.AutolinkStyle = AutolinkStyleNone
+	}
+
+	if cfg.ImageSourceStrategy == "" {
+		cfg.ImageSourceStrategy = ImageSourceStrategySrc
+	}
+	if cfg.ImageFallbackAttributes == nil {
+		cfg.ImageFallbackAttributes = []string{"data-src", "data-lazy-src", "data-original"}
+	}
 
 	return *cfg
 }
@@ -0,0 +1,164 @@
+package commonmark
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+// https://spec.commonmark.org/0.31.2/#autolinks
+var (
+	uriAutolinkRegex   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\x00-\x20<>]*$`)
+	emailAutolinkRegex = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+// https://github.github.com/gfm/#autolinks-extension-
+var (
+	urlLiteralRegex   = regexp.MustCompile(`^(?i:https?://|www\.)[a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)+(?:[/?#][^\x00-\x20<>*~\x60\[\]\\]*)?$`)
+	emailLiteralRegex = regexp.MustCompile(`^[a-zA-Z0-9._+-]+@[a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)+$`)
+)
+
+// autolinkText returns the text of the link if it can be rendered as
+// an autolink, e.g. "https://example.com" or the address of a "mailto:" link.
+func autolinkText(n *html.Node, href string) (string, bool) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.TextNode {
+			// The formatting of the content would get lost
+			return "", false
+		}
+	}
+	text := strings.TrimSpace(dom.CollectText(n))
+	if text == "" {
+		return "", false
+	}
+
+	switch {
+	case text == href:
+		return text, true
+	case strings.HasPrefix(strings.ToLower(href), "mailto:") && href[len("mailto:"):] == text:
+		return text, true
+	case strings.HasPrefix(text, "www.") && href == "http://"+text:
+		// Only for the autolink literals, since the angle brackets need a scheme.
+		return text, true
+	default:
+		return "", false
+	}
+}
+
+// isAutolinkDelimiter reports whether an autolink literal
+// can come after this character.
+func isAutolinkDelimiter(r rune) bool {
+	return r == 0 || unicode.IsSpace(r) || r == '*' || r == '_' || r == '~' || r == '('
+}
+
+// isAutolinkTerminator reports whether an autolink literal
+// ends before this (and the following) character.
+func isAutolinkTerminator(r rune, after rune) bool {
+	if r == 0 || unicode.IsSpace(r) || r == '<' {
+		return true
+	}
+	// The trailing punctuation is not considered part of the autolink literal
+	if strings.ContainsRune(`?!.,:;*_~)'"`, r) {
+		return after == 0 || unicode.IsSpace(after)
+	}
+	return false
+}
+
+// adjacentText returns the text that is rendered directly before (or after)
+// the node within the same block. Line breaks are returned as a newline.
+func adjacentText(n *html.Node, before bool) string {
+	sibling := func(node *html.Node) *html.Node {
+		if before {
+			return node.PrevSibling
+		}
+		return node.NextSibling
+	}
+
+	for node := n; node != nil; node = node.Parent {
+		for s := sibling(node); s != nil; s = sibling(s) {
+			name := dom.NodeName(s)
+			if name == "br" || dom.NameIsBlockNode(name) {
+				return "\n"
+			}
+			if text := dom.CollectText(s); text != "" {
+				return text
+			}
+		}
+
+		if node.Parent == nil || dom.NameIsBlockNode(dom.NodeName(node.Parent)) {
+			break
+		}
+	}
+	return ""
+}
+
+// canBeAutolinkLiteral checks if the link text would be recognized
+// as an autolink literal, also taking the surrounding text into account.
+func canBeAutolinkLiteral(n *html.Node, l *link, text string) bool {
+	if !urlLiteralRegex.MatchString(text) && !emailLiteralRegex.MatchString(text) {
+		return false
+	}
+	if !strings.HasPrefix(text, "www.") && !strings.HasPrefix(strings.ToLower(text), "http") && !strings.HasPrefix(strings.ToLower(l.href), "mailto:") {
+		// e.g. an email address that links to a website
+		return false
+	}
+	if strings.Count(text, "(") != strings.Count(text, ")") {
+		return false
+	}
+
+	last, _ := utf8.DecodeLastRuneInString(text)
+	if isAutolinkTerminator(last, 0) || last == '-' {
+		// The last character would not be part of the autolink literal
+		return false
+	}
+
+	if len(l.before) == 0 {
+		prev := []rune(adjacentText(n, true))
+		if len(prev) != 0 && !isAutolinkDelimiter(prev[len(prev)-1]) {
+			return false
+		}
+	}
+	if len(l.after) == 0 {
+		next := append([]rune(adjacentText(n, false)), 0, 0)
+		if !isAutolinkTerminator(next[0], next[1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// renderAutolink renders the link as an autolink if possible
+// and otherwise returns false.
+func (c *commonmark) renderAutolink(w converter.Writer, n *html.Node, l *link) bool {
+	if c.AutolinkStyle == AutolinkStyleNone || l.title != "" {
+		return false
+	}
+
+	text, ok := autolinkText(n, l.href)
+	if !ok {
+		return false
+	}
+
+	var content string
+	switch {
+	case c.AutolinkStyle == AutolinkStyleLiteral && canBeAutolinkLiteral(n, l, text):
+		content = text
+	case text == l.href && uriAutolinkRegex.MatchString(text):
+		content = "<" + text + ">"
+	case text != l.href && emailAutolinkRegex.MatchString(text):
+		content = "<" + text + ">"
+	default:
+		return false
+	}
+
+	w.Write(l.before)
+	w.WriteString(content)
+	w.Write(l.after)
+	return true
+}
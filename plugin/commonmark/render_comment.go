@@ -6,14 +6,14 @@ import (
 	"golang.org/x/net/html"
 )
 
-func (c *commonmark) renderComment(_ converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+func (c *commonmark) renderComment(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
 
 	if n.Data == domutils.ListEndCommentData {
 		// We definitely want to render the list end comments
 		// that were just added
 		w.WriteRune('\n')
 		w.WriteRune('\n')
-		_ = html.Render(w, n)
+		_ = converter.RenderHTML(ctx, w, n)
 		w.WriteRune('\n')
 		w.WriteRune('\n')
 		return converter.RenderSuccess
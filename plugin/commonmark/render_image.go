@@ -28,13 +28,16 @@ func escapeAlt(altString string) string {
 }
 
 func (c *commonmark) renderImage(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
-	src := dom.GetAttributeOr(n, "src", "")
-	src = strings.TrimSpace(src)
+	src := c.selectImageSource(n)
 	if src == "" {
 		return converter.RenderTryNext
 	}
 
-	src = ctx.AssembleAbsoluteURL(ctx, "img", src)
+	src, keep := converter.ResolveURL(ctx, "img", "src", src)
+	if !keep {
+		// A url rewriter dropped the image.
+		return converter.RenderSuccess
+	}
 
 	title := dom.GetAttributeOr(n, "title", "")
 	title = strings.ReplaceAll(title, "\n", " ")
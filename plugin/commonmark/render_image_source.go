@@ -0,0 +1,175 @@
+package commonmark
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"net/url"
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/domutils"
+	"golang.org/x/net/html"
+)
+
+type imageSourceCandidate = domutils.SrcsetCandidate
+
+// largestCandidate prefers the width descriptor ("960w") over the density descriptor ("2x").
+func largestCandidate(candidates []imageSourceCandidate) (imageSourceCandidate, bool) {
+	if len(candidates) == 0 {
+		return imageSourceCandidate{}, false
+	}
+
+	largest := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.Width > largest.Width || (c.Width == largest.Width && c.Density > largest.Density) {
+			largest = c
+		}
+	}
+	return largest, true
+}
+
+// closestCandidate returns the smallest candidate that is at least as wide
+// as the width. If all candidates are smaller, the largest one is used.
+func closestCandidate(candidates []imageSourceCandidate, width int) (imageSourceCandidate, bool) {
+	var closest *imageSourceCandidate
+	for i, c := range candidates {
+		if c.Width < width {
+			continue
+		}
+		if closest == nil || c.Width < closest.Width {
+			closest = &candidates[i]
+		}
+	}
+	if closest != nil {
+		return *closest, true
+	}
+	return largestCandidate(candidates)
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+// isPlaceholderImage detects the tiny images (e.g. 1x1 gif) that are used by
+// lazy loading libraries until the real image is loaded.
+func isPlaceholderImage(src string) bool {
+	if src == "about:blank" || src == "#" {
+		return true
+	}
+
+	meta, content, ok := strings.Cut(src, ",")
+	if !ok || !strings.HasPrefix(strings.ToLower(meta), "data:image/") {
+		return false
+	}
+	if !strings.HasSuffix(strings.ToLower(meta), ";base64") {
+		// An svg with a width and height of 1 is also a placeholder
+		content, err := url.PathUnescape(content)
+		if err != nil {
+			return false
+		}
+		content = strings.ReplaceAll(content, `"`, "'")
+		return strings.Contains(content, "width='1'") && strings.Contains(content, "height='1'")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case len(data) >= 10 && string(data[:3]) == "GIF":
+		width := binary.LittleEndian.Uint16(data[6:8])
+		height := binary.LittleEndian.Uint16(data[8:10])
+		return width <= 1 && height <= 1
+
+	case len(data) >= 24 && string(data[1:4]) == "PNG":
+		width := binary.BigEndian.Uint32(data[16:20])
+		height := binary.BigEndian.Uint32(data[20:24])
+		return width <= 1 && height <= 1
+	}
+	return false
+}
+
+// getAttributeWithFallback returns the value of the attribute. If it is empty
+// or a placeholder, the first of the fallback attributes with a value is used.
+func getAttributeWithFallback(n *html.Node, key string, fallbacks []string) string {
+	val := strings.TrimSpace(dom.GetAttributeOr(n, key, ""))
+	if val != "" && !isPlaceholderImage(val) {
+		return val
+	}
+
+	for _, fallback := range fallbacks {
+		fallbackVal := strings.TrimSpace(dom.GetAttributeOr(n, fallback, ""))
+		if fallbackVal != "" {
+			return fallbackVal
+		}
+	}
+	return val
+}
+
+// pictureSources returns the <source> elements of the surrounding <picture>.
+func pictureSources(img *html.Node) []*html.Node {
+	if img.Parent == nil || dom.NodeName(img.Parent) != "picture" {
+		return nil
+	}
+
+	var sources []*html.Node
+	for child := img.Parent.FirstChild; child != nil; child = child.NextSibling {
+		if dom.NodeName(child) == "source" {
+			sources = append(sources, child)
+		}
+	}
+	return sources
+}
+
+func srcsetCandidates(n *html.Node) []imageSourceCandidate {
+	srcset := getAttributeWithFallback(n, "srcset", []string{"data-srcset"})
+	return domutils.ParseSrcset(srcset)
+}
+
+// allSrcsetCandidates returns the candidates of the <img> and the <source> elements.
+func allSrcsetCandidates(img *html.Node) []imageSourceCandidate {
+	var candidates []imageSourceCandidate
+	for _, source := range pictureSources(img) {
+		candidates = append(candidates, srcsetCandidates(source)...)
+	}
+	return append(candidates, srcsetCandidates(img)...)
+}
+
+// selectImageSource chooses the url of the image based on the ImageSourceStrategy.
+func (c *commonmark) selectImageSource(n *html.Node) string {
+	src := getAttributeWithFallback(n, "src", c.ImageFallbackAttributes)
+
+	var candidate imageSourceCandidate
+	var found bool
+
+	switch c.ImageSourceStrategy {
+	case ImageSourceStrategyLargest:
+		candidate, found = largestCandidate(allSrcsetCandidates(n))
+
+	case ImageSourceStrategyWidth:
+		candidate, found = closestCandidate(allSrcsetCandidates(n), c.ImageSourceWidth)
+
+	case ImageSourceStrategyType:
+		for _, source := range pictureSources(n) {
+			sourceType := strings.TrimSpace(dom.GetAttributeOr(source, "type", ""))
+			if !strings.EqualFold(sourceType, c.ImageSourceType) {
+				continue
+			}
+
+			candidate, found = largestCandidate(srcsetCandidates(source))
+			if found {
+				break
+			}
+		}
+	}
+
+	if !found && (src == "" || isPlaceholderImage(src)) {
+		// Without a (real) src we can still use the srcset,
+		// instead of rendering a placeholder or nothing.
+		candidate, found = largestCandidate(allSrcsetCandidates(n))
+	}
+
+	if found {
+		return candidate.URL
+	}
+	return src
+}
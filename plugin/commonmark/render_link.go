@@ -50,7 +50,12 @@ func (c *commonmark) renderLink(ctx converter.Context, w converter.Writer, n *ht
 	href := dom.GetAttributeOr(n, "href", "")
 
 	href = strings.TrimSpace(href)
-	href = ctx.AssembleAbsoluteURL(ctx, "a", href)
+	href, keep := converter.ResolveURL(ctx, "a", "href", href)
+	if !keep {
+		// A url rewriter dropped the link, so only the content
+		// (without the link) will be rendered by other renderers.
+		return converter.RenderTryNext
+	}
 
 	if href == "" && c.config.LinkEmptyHrefBehavior == LinkBehaviorSkip {
 		// There is *no href* for the link. Now we have two options:
@@ -94,6 +99,10 @@ func (c *commonmark) renderLink(ctx converter.Context, w converter.Writer, n *ht
 	l.content = trimmed
 	l.after = rightExtra
 
+	if c.renderAutolink(w, n, l) {
+		return converter.RenderSuccess
+	}
+
 	switch c.LinkStyle {
 	case LinkStyleInlined:
 		return c.renderLinkInlined(w, l)
@@ -88,6 +88,32 @@ func validateConfig(cfg *config) error {
 		}
 	}
 
+	possibleImageSourceStrategies := []string{
+		string(ImageSourceStrategySrc), string(ImageSourceStrategyLargest),
+		string(ImageSourceStrategyWidth), string(ImageSourceStrategyType),
+	}
+	if !contains(possibleImageSourceStrategies, string(cfg.ImageSourceStrategy)) {
+		return &ValidateConfigError{
+			Key:                "ImageSourceStrategy",
+			Value:              string(cfg.ImageSourceStrategy),
+			patternDescription: `one of "src", "largest", "width" or "type"`,
+		}
+	}
+	if cfg.ImageSourceStrategy == ImageSourceStrategyWidth && cfg.ImageSourceWidth <= 0 {
+		return &ValidateConfigError{
+			Key:                "ImageSourceWidth",
+			Value:              fmt.Sprint(cfg.ImageSourceWidth),
+			patternDescription: `greater than zero for the "width" strategy`,
+		}
+	}
+	if cfg.ImageSourceStrategy == ImageSourceStrategyType && strings.TrimSpace(cfg.ImageSourceType) == "" {
+		return &ValidateConfigError{
+			Key:                "ImageSourceType",
+			Value:              cfg.ImageSourceType,
+			patternDescription: `a mime type (e.g. "image/webp") for the "type" strategy`,
+		}
+	}
+
 	possibleLinkStyles := []string{string(LinkStyleInlined), string(LinkStyleReferencedIndex), string(LinkStyleReferencedShort)}
 	if !contains(possibleLinkStyles, string(cfg.LinkStyle)) {
 		return &ValidateConfigError{
@@ -97,5 +123,14 @@ func validateConfig(cfg *config) error {
 		}
 	}
 
+	possibleAutolinkStyles := []string{string(AutolinkStyleNone), string(AutolinkStyleAngleBrackets), string(AutolinkStyleLiteral)}
+	if !contains(possibleAutolinkStyles, string(cfg.AutolinkStyle)) {
+		return &ValidateConfigError{
+			Key:                "AutolinkStyle",
+			Value:              string(cfg.AutolinkStyle),
+			patternDescription: `one of "none", "angle_brackets" or "literal"`,
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,264 @@
+package embeds
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/textutils"
+	"golang.org/x/net/html"
+)
+
+type option func(p *embedsPlugin) error
+
+type EmbedStyle string
+
+const (
+	// EmbedStyleThumbnail renders an image of the thumbnail (or the `poster` of a video)
+	// that links to the embedded content (default). Without a thumbnail a plain link is used.
+	EmbedStyleThumbnail EmbedStyle = "thumbnail"
+	// EmbedStyleLink renders a link with the title as the text.
+	EmbedStyleLink EmbedStyle = "link"
+	// EmbedStyleHTML keeps the html of the element as it is.
+	EmbedStyleHTML EmbedStyle = "html"
+)
+
+// WithEmbedStyle configures how the recognized iframes, videos and audios are rendered.
+func WithEmbedStyle(style EmbedStyle) option {
+	return func(p *embedsPlugin) error {
+		switch style {
+		case "":
+			return nil
+
+		case EmbedStyleThumbnail, EmbedStyleLink, EmbedStyleHTML:
+			p.style = style
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for embed style", style)
+		}
+	}
+}
+
+// WithProviders adds providers that are checked before the `DefaultProviders`.
+func WithProviders(providers ...Provider) option {
+	return func(p *embedsPlugin) error {
+		for _, provider := range providers {
+			if provider.Match == nil {
+				return fmt.Errorf("the provider %q has no match function", provider.Name)
+			}
+		}
+		p.providers = append(p.providers, providers...)
+		return nil
+	}
+}
+
+type embedsPlugin struct {
+	m   sync.RWMutex
+	err error
+
+	style     EmbedStyle
+	providers []Provider
+}
+
+func (p *embedsPlugin) setError(err error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.err = err
+}
+func (p *embedsPlugin) getError() error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	return p.err
+}
+
+// NewEmbedsPlugin converts `<iframe>` embeds of known providers
+// (YouTube, Vimeo, Spotify, Apple Podcasts, SoundCloud, CodePen)
+// as well as `<video>` and `<audio>` elements.
+//
+// Iframes of unknown providers are still removed.
+func NewEmbedsPlugin(opts ...option) converter.Plugin {
+	plugin := &embedsPlugin{
+		style: EmbedStyleThumbnail,
+	}
+	for _, opt := range opts {
+		err := opt(plugin)
+		if err != nil {
+			plugin.setError(err)
+			break
+		}
+	}
+	plugin.providers = append(plugin.providers, DefaultProviders...)
+
+	return plugin
+}
+
+func (p *embedsPlugin) Name() string {
+	return "embeds"
+}
+
+func (p *embedsPlugin) Init(conv *converter.Converter) error {
+	if err := p.getError(); err != nil {
+		// Any error raised from the option func
+		return err
+	}
+
+	// The base plugin removes all iframes. The higher priority
+	// keeps them around so that they reach the renderer.
+	conv.Register.RendererFor("iframe", converter.TagTypeBlock, p.renderIframe, converter.PriorityEarly)
+	conv.Register.RendererFor("video", converter.TagTypeBlock, p.renderMedia, converter.PriorityEarly)
+	conv.Register.RendererFor("audio", converter.TagTypeBlock, p.renderMedia, converter.PriorityEarly)
+
+	return nil
+}
+
+func (p *embedsPlugin) findEmbed(rawURL string) (Embed, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return Embed{}, false
+	}
+
+	for _, provider := range p.providers {
+		if embed, ok := provider.Match(u); ok {
+			if embed.Provider == "" {
+				embed.Provider = provider.Name
+			}
+			return embed, true
+		}
+	}
+	return Embed{}, false
+}
+
+func (p *embedsPlugin) renderIframe(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	// The providers match against the absolute url, so a relative
+	// src (or one relative to the <base href>) needs to be resolved first.
+	src, keep := converter.ResolveURL(ctx, "iframe", "src", strings.TrimSpace(dom.GetAttributeOr(n, "src", "")))
+	if !keep {
+		// A url rewriter dropped the iframe.
+		return converter.RenderSuccess
+	}
+
+	embed, ok := p.findEmbed(src)
+	if !ok {
+		// Same as the base plugin: unknown iframes are removed.
+		return converter.RenderSuccess
+	}
+
+	title := getTitle(n)
+	if title == "" {
+		title = embed.Title
+	}
+
+	return p.render(ctx, w, n, embed.URL, embed.ThumbnailURL, title)
+}
+
+func (p *embedsPlugin) renderMedia(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	name := dom.NodeName(n)
+
+	src := mediaSource(n)
+	if src == "" {
+		// Nothing to link to, so we fall back to the content
+		// (which is normally a message for old browsers).
+		return converter.RenderTryNext
+	}
+	// The <a> and <img> that are rendered below are resolved (again) by
+	// the commonmark plugin. So only the raw urls are passed on, that way
+	// the url rewriters are not applied twice to the same url.
+	if _, keep := converter.ResolveURL(ctx, name, "src", src); !keep {
+		// A url rewriter dropped the source, so we fall back to the content.
+		return converter.RenderTryNext
+	}
+
+	title := getTitle(n)
+	if title == "" {
+		if name == "video" {
+			title = "Video"
+		} else {
+			title = "Audio"
+		}
+	}
+
+	poster := strings.TrimSpace(dom.GetAttributeOr(n, "poster", ""))
+	if poster != "" {
+		if _, keep := converter.ResolveURL(ctx, name, "poster", poster); !keep {
+			poster = ""
+		}
+	}
+
+	return p.render(ctx, w, n, src, poster, title)
+}
+
+func (p *embedsPlugin) render(ctx converter.Context, w converter.Writer, n *html.Node, href, thumbnail, title string) converter.RenderStatus {
+	w.WriteString("\n\n")
+	defer w.WriteString("\n\n")
+
+	if p.style == EmbedStyleHTML {
+		var buf bytes.Buffer
+		converter.RenderHTML(ctx, &buf, n)
+
+		// A blank line would end the html block, so that the
+		// rest of the element would be interpreted as markdown.
+		w.WriteString(textutils.EncodeBlankLines(buf.String()))
+		return converter.RenderSuccess
+	}
+
+	// By rendering ordinary <a> and <img> nodes the link style,
+	// escaping and url rewriters of the other plugins are respected.
+	link := &html.Node{
+		Type: html.ElementNode,
+		Data: "a",
+		Attr: []html.Attribute{{Key: "href", Val: href}},
+	}
+	if p.style == EmbedStyleThumbnail && thumbnail != "" {
+		link.AppendChild(&html.Node{
+			Type: html.ElementNode,
+			Data: "img",
+			Attr: []html.Attribute{
+				{Key: "src", Val: thumbnail},
+				{Key: "alt", Val: title},
+			},
+		})
+	} else {
+		link.AppendChild(&html.Node{
+			Type: html.TextNode,
+			Data: title,
+		})
+	}
+
+	ctx.RenderNodes(ctx, w, link)
+	return converter.RenderSuccess
+}
+
+// getTitle returns the title (or aria-label) with the whitespace collapsed.
+func getTitle(n *html.Node) string {
+	title := dom.GetAttributeOr(n, "title", "")
+	if strings.TrimSpace(title) == "" {
+		title = dom.GetAttributeOr(n, "aria-label", "")
+	}
+
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// mediaSource returns the `src` of the element or otherwise
+// the `src` of the first <source> child.
+func mediaSource(n *html.Node) string {
+	if src := strings.TrimSpace(dom.GetAttributeOr(n, "src", "")); src != "" {
+		return src
+	}
+
+	for _, child := range dom.AllChildNodes(n) {
+		if dom.NodeName(child) != "source" {
+			continue
+		}
+		if src := strings.TrimSpace(dom.GetAttributeOr(child, "src", "")); src != "" {
+			return src
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+package embeds
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/tester"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func TestNewEmbedsPlugin(t *testing.T) {
+	runs := []struct {
+		desc     string
+		options  []option
+		input    string
+		expected string
+	}{
+		{
+			desc:     "youtube thumbnail",
+			input:    `<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" title="The Video"></iframe>`,
+			expected: `[![The Video](https://img.youtube.com/vi/dQw4w9WgXcQ/hqdefault.jpg)](https://www.youtube.com/watch?v=dQw4w9WgXcQ)`,
+		},
+		{
+			desc:     "youtube without title",
+			input:    `<iframe src="//www.youtube-nocookie.com/embed/dQw4w9WgXcQ?autoplay=1"></iframe>`,
+			expected: `[![YouTube video](https://img.youtube.com/vi/dQw4w9WgXcQ/hqdefault.jpg)](https://www.youtube.com/watch?v=dQw4w9WgXcQ)`,
+		},
+		{
+			desc: "youtube link",
+			options: []option{
+				WithEmbedStyle(EmbedStyleLink),
+			},
+			input:    `<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" title="The *Video*"></iframe>`,
+			expected: `[The \*Video\*](https://www.youtube.com/watch?v=dQw4w9WgXcQ)`,
+		},
+		{
+			desc: "youtube html",
+			options: []option{
+				WithEmbedStyle(EmbedStyleHTML),
+			},
+			input:    `<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" allowfullscreen></iframe>`,
+			expected: `<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" allowfullscreen=""></iframe>`,
+		},
+		{
+			desc:     "vimeo without thumbnail",
+			input:    `<iframe src="https://player.vimeo.com/video/76979871?h=8272103f6e" title="The New Vimeo Player"></iframe>`,
+			expected: `[The New Vimeo Player](https://vimeo.com/76979871)`,
+		},
+		{
+			desc:     "spotify episode",
+			input:    `<iframe src="https://open.spotify.com/embed/episode/7makk4oTQel546B0PZlDM5?utm_source=generator"></iframe>`,
+			expected: `[Spotify episode](https://open.spotify.com/episode/7makk4oTQel546B0PZlDM5)`,
+		},
+		{
+			desc:     "apple podcasts",
+			input:    `<iframe src="https://embed.podcasts.apple.com/us/podcast/the-daily/id1200361736?i=1000"></iframe>`,
+			expected: `[Apple Podcasts episode](https://podcasts.apple.com/us/podcast/the-daily/id1200361736?i=1000)`,
+		},
+		{
+			desc:     "soundcloud",
+			input:    `<iframe src="https://w.soundcloud.com/player/?url=https%3A//api.soundcloud.com/tracks/293&auto_play=false"></iframe>`,
+			expected: `[SoundCloud audio](https://api.soundcloud.com/tracks/293)`,
+		},
+		{
+			desc:     "codepen",
+			input:    `<iframe src="https://codepen.io/someone/embed/preview/abcdef?default-tab=result"></iframe>`,
+			expected: `[CodePen](https://codepen.io/someone/pen/abcdef)`,
+		},
+		{
+			desc:     "unknown iframe",
+			input:    `<p>A</p><iframe src="https://example.com/widget"></iframe><p>B</p>`,
+			expected: "A\n\nB",
+		},
+		{
+			desc: "custom provider",
+			options: []option{
+				WithProviders(Provider{
+					Name: "Example",
+					Match: func(u *url.URL) (Embed, bool) {
+						if u.Host != "example.com" || !strings.HasPrefix(u.Path, "/embed/") {
+							return Embed{}, false
+						}
+						return Embed{
+							URL:   "https://example.com/watch/" + strings.TrimPrefix(u.Path, "/embed/"),
+							Title: "Example",
+						}, true
+					},
+				}),
+			},
+			input:    `<iframe src="https://example.com/embed/123"></iframe>`,
+			expected: `[Example](https://example.com/watch/123)`,
+		},
+		{
+			desc: "video with poster and source",
+			input: `<video poster="/poster.jpg" controls>
+	<source src="/movie.webm" type="video/webm" />
+	<source src="/movie.mp4" type="video/mp4" />
+	Your browser does not support the video tag.
+</video>`,
+			expected: `[![Video](/poster.jpg)](/movie.webm)`,
+		},
+		{
+			desc:     "video with title",
+			input:    `<video src="/movie.mp4" title="The Movie"></video>`,
+			expected: `[The Movie](/movie.mp4)`,
+		},
+		{
+			desc:     "video without source",
+			input:    `<video>Your browser does not support the video tag.</video>`,
+			expected: `Your browser does not support the video tag.`,
+		},
+		{
+			desc: "video html without event handlers",
+			options: []option{
+				WithEmbedStyle(EmbedStyleHTML),
+			},
+			input:    `<video src="a.mp4" onplay="alert(1)" controls></video>`,
+			expected: `<video src="a.mp4" controls=""></video>`,
+		},
+		{
+			desc: "video html with blank lines",
+			options: []option{
+				WithEmbedStyle(EmbedStyleHTML),
+			},
+			input:    "<video src=\"a.mp4\"><pre>a\n\nb</pre></video>",
+			expected: "<video src=\"a.mp4\"><pre>a\n&#10;b</pre></video>",
+		},
+		{
+			desc:     "audio",
+			input:    `<audio controls src="/podcast.mp3" aria-label="Episode 1"></audio>`,
+			expected: `[Episode 1](/podcast.mp3)`,
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewEmbedsPlugin(run.options...),
+				),
+			)
+
+			out, err := conv.ConvertString(run.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, out)
+			}
+		})
+	}
+}
+
+func TestRelativeSource(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewEmbedsPlugin(
+				WithEmbedStyle(EmbedStyleLink),
+			),
+		),
+		converter.WithURLRewriter(func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+			if strings.Contains(info.RawURL, "ads") {
+				return "", false
+			}
+			return info.URL, true
+		}),
+	)
+
+	input := `<html><head><base href="https://www.youtube.com/"></head><body>
+<iframe src="/embed/dQw4w9WgXcQ" title="The Video"></iframe>
+<iframe src="/embed/ads"></iframe>
+<video src="/ads.mp4">Your browser does not support the video tag.</video>
+</body></html>`
+
+	out, err := conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "[The Video](https://www.youtube.com/watch?v=dQw4w9WgXcQ)\n\nYour browser does not support the video tag."
+	if out != expected {
+		t.Errorf("expected %q but got %q", expected, out)
+	}
+}
+
+func TestWithEmbedStyle_Invalid(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewEmbedsPlugin(
+				WithEmbedStyle("image"),
+			),
+		),
+	)
+
+	_, err := conv.ConvertString("<p>text</p>")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := `error while initializing "embeds" plugin: unknown value "image" for embed style`
+	if err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
+
+func TestGoldenFiles(t *testing.T) {
+	goldenFileConvert := func(htmlInput []byte) ([]byte, error) {
+		conv := converter.NewConverter(
+			converter.WithPlugins(
+				base.NewBasePlugin(),
+				commonmark.NewCommonmarkPlugin(),
+				NewEmbedsPlugin(),
+			),
+		)
+
+		return conv.ConvertReader(bytes.NewReader(htmlInput))
+	}
+
+	tester.GoldenFiles(t, goldenFileConvert, goldenFileConvert)
+}
@@ -0,0 +1,196 @@
+package embeds
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Embed describes the content behind an embed url.
+type Embed struct {
+	// Provider is the name of the service, e.g. "YouTube".
+	Provider string
+
+	// URL is the page that can be opened in the browser,
+	// e.g. "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	URL string
+
+	// ThumbnailURL is a preview image. It can be empty
+	// if the provider has no predictable thumbnail url.
+	ThumbnailURL string
+
+	// Title is used if the element itself has no title.
+	Title string
+}
+
+// Provider recognizes the embed urls of one service.
+type Provider struct {
+	Name string
+
+	// Match is called with the (absolute) src of the iframe
+	// and returns false if the url does not belong to this provider.
+	Match func(u *url.URL) (Embed, bool)
+}
+
+// DefaultProviders are the providers that are checked
+// after the ones from `WithProviders`.
+var DefaultProviders = []Provider{
+	{Name: "YouTube", Match: matchYouTube},
+	{Name: "Vimeo", Match: matchVimeo},
+	{Name: "Spotify", Match: matchSpotify},
+	{Name: "Apple Podcasts", Match: matchApplePodcasts},
+	{Name: "SoundCloud", Match: matchSoundCloud},
+	{Name: "CodePen", Match: matchCodePen},
+}
+
+// hostIs checks the host without the "www." and "m." prefixes.
+func hostIs(u *url.URL, hosts ...string) bool {
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	host = strings.TrimPrefix(host, "m.")
+
+	for _, h := range hosts {
+		if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+// pathSegments splits the path into its non-empty parts.
+func pathSegments(u *url.URL) []string {
+	var segments []string
+	for _, s := range strings.Split(u.Path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+func matchYouTube(u *url.URL) (Embed, bool) {
+	var id string
+	segments := pathSegments(u)
+
+	switch {
+	case hostIs(u, "youtube.com", "youtube-nocookie.com"):
+		// e.g. "/embed/dQw4w9WgXcQ" or "/v/dQw4w9WgXcQ"
+		if len(segments) == 2 && (segments[0] == "embed" || segments[0] == "v") {
+			id = segments[1]
+		}
+	case hostIs(u, "youtu.be"):
+		if len(segments) == 1 {
+			id = segments[0]
+		}
+	}
+	if id == "" || id == "videoseries" {
+		return Embed{}, false
+	}
+
+	return Embed{
+		Provider:     "YouTube",
+		URL:          "https://www.youtube.com/watch?v=" + url.QueryEscape(id),
+		ThumbnailURL: "https://img.youtube.com/vi/" + url.PathEscape(id) + "/hqdefault.jpg",
+		Title:        "YouTube video",
+	}, true
+}
+
+func matchVimeo(u *url.URL) (Embed, bool) {
+	if !hostIs(u, "player.vimeo.com") {
+		return Embed{}, false
+	}
+
+	// e.g. "/video/76979871"
+	segments := pathSegments(u)
+	if len(segments) != 2 || segments[0] != "video" {
+		return Embed{}, false
+	}
+
+	return Embed{
+		Provider: "Vimeo",
+		URL:      "https://vimeo.com/" + url.PathEscape(segments[1]),
+		Title:    "Vimeo video",
+	}, true
+}
+
+func matchSpotify(u *url.URL) (Embed, bool) {
+	if !hostIs(u, "open.spotify.com") {
+		return Embed{}, false
+	}
+
+	// e.g. "/embed/episode/7makk4oTQel546B0PZlDM5" or "/embed-podcast/show/..."
+	segments := pathSegments(u)
+	if len(segments) != 3 || !strings.HasPrefix(segments[0], "embed") {
+		return Embed{}, false
+	}
+
+	kind := segments[1]
+	switch kind {
+	case "episode", "show", "track", "album", "playlist", "artist":
+	default:
+		return Embed{}, false
+	}
+
+	return Embed{
+		Provider: "Spotify",
+		URL:      "https://open.spotify.com/" + kind + "/" + url.PathEscape(segments[2]),
+		Title:    "Spotify " + kind,
+	}, true
+}
+
+func matchApplePodcasts(u *url.URL) (Embed, bool) {
+	if !hostIs(u, "embed.podcasts.apple.com") || u.Path == "" || u.Path == "/" {
+		return Embed{}, false
+	}
+
+	// The embed has the same path as the page, e.g. "/us/podcast/name/id123?i=456"
+	page := url.URL{
+		Scheme:   "https",
+		Host:     "podcasts.apple.com",
+		Path:     u.Path,
+		RawQuery: u.RawQuery,
+	}
+	return Embed{
+		Provider: "Apple Podcasts",
+		URL:      page.String(),
+		Title:    "Apple Podcasts episode",
+	}, true
+}
+
+func matchSoundCloud(u *url.URL) (Embed, bool) {
+	if !hostIs(u, "w.soundcloud.com") {
+		return Embed{}, false
+	}
+
+	// e.g. "/player/?url=https%3A//api.soundcloud.com/tracks/123"
+	trackURL, err := url.Parse(u.Query().Get("url"))
+	if err != nil || trackURL.Host == "" {
+		return Embed{}, false
+	}
+
+	return Embed{
+		Provider: "SoundCloud",
+		URL:      trackURL.String(),
+		Title:    "SoundCloud audio",
+	}, true
+}
+
+func matchCodePen(u *url.URL) (Embed, bool) {
+	if !hostIs(u, "codepen.io") {
+		return Embed{}, false
+	}
+
+	// e.g. "/username/embed/abcdef" or "/username/embed/preview/abcdef"
+	segments := pathSegments(u)
+	if len(segments) < 3 || segments[1] != "embed" {
+		return Embed{}, false
+	}
+
+	user := segments[0]
+	id := segments[len(segments)-1]
+	return Embed{
+		Provider: "CodePen",
+		URL:      "https://codepen.io/" + path.Join(url.PathEscape(user), "pen", url.PathEscape(id)),
+		Title:    "CodePen",
+	}, true
+}
@@ -0,0 +1,285 @@
+package emoji
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+type option func(p *emojiPlugin) error
+
+type EmojiStyle string
+
+const (
+	// EmojiStyleUnicode renders the emoji as the unicode character, e.g. "😄" (default)
+	EmojiStyleUnicode EmojiStyle = "unicode"
+	// EmojiStyleShortcode renders the shortcode of GitHub, e.g. ":smile:"
+	//
+	// Emojis without a known shortcode are rendered as the unicode character.
+	EmojiStyleShortcode EmojiStyle = "shortcode"
+)
+
+// WithEmojiStyle configures how the emojis are rendered.
+//
+//	"unicode" or "shortcode"
+func WithEmojiStyle(style EmojiStyle) option {
+	return func(p *emojiPlugin) error {
+		switch style {
+		case EmojiStyleUnicode, EmojiStyleShortcode:
+			p.style = style
+			return nil
+		default:
+			return fmt.Errorf("unknown value %q for emoji style", style)
+		}
+	}
+}
+
+type emojiPlugin struct {
+	m   sync.RWMutex
+	err error
+
+	style EmojiStyle
+}
+
+func (p *emojiPlugin) setError(err error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.err = err
+}
+func (p *emojiPlugin) getError() error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	return p.err
+}
+
+// NewEmojiPlugin converts the images of emojis (e.g. `<img class="emoji" alt="😄">`)
+// and the `<g-emoji>` elements of GitHub to the emoji itself,
+// instead of an image that links to the picture of the emoji.
+func NewEmojiPlugin(opts ...option) converter.Plugin {
+	plugin := &emojiPlugin{
+		style: EmojiStyleUnicode,
+	}
+	for _, opt := range opts {
+		err := opt(plugin)
+		if err != nil {
+			plugin.setError(err)
+			break
+		}
+	}
+	return plugin
+}
+
+func (p *emojiPlugin) Name() string {
+	return "emoji"
+}
+
+func (p *emojiPlugin) Init(conv *converter.Converter) error {
+	if err := p.getError(); err != nil {
+		// Any error raised from the option func
+		return err
+	}
+
+	conv.Register.PreRenderer(p.handlePreRender, converter.PriorityStandard)
+
+	// Note: The priority is high, so that the emoji images
+	// are handled before the images of the commonmark plugin.
+	conv.Register.RendererFor("img", converter.TagTypeInline, p.renderImage, converter.PriorityEarly)
+	conv.Register.RendererFor("g-emoji", converter.TagTypeInline, p.renderGEmoji, converter.PriorityStandard)
+
+	if p.style == EmojiStyleShortcode {
+		conv.Register.EscapedChar(':')
+		conv.Register.UnEscaper(isShortcode, converter.PriorityStandard)
+	}
+
+	return nil
+}
+
+// emoji is the result of the detection. Either of the fields can be empty.
+type emoji struct {
+	unicode   string
+	shortcode string
+}
+
+func (p *emojiPlugin) write(w converter.Writer, e emoji) {
+	if e.unicode != "" && e.shortcode == "" {
+		e.shortcode, _ = lookupShortcode(e.unicode)
+	}
+	if e.shortcode != "" && e.unicode == "" {
+		e.unicode, _ = lookupEmoji(e.shortcode)
+	}
+
+	if (p.style == EmojiStyleShortcode && e.shortcode != "") || e.unicode == "" {
+		w.WriteString(":" + e.shortcode + ":")
+		return
+	}
+	w.WriteString(e.unicode)
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+// parseShortcode returns "smile" for ":smile:"
+func parseShortcode(text string) (string, bool) {
+	if len(text) < 3 || !strings.HasPrefix(text, ":") || !strings.HasSuffix(text, ":") {
+		return "", false
+	}
+
+	name := text[1 : len(text)-1]
+	for _, r := range name {
+		if !isShortcodeChar(r) {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+func isShortcodeChar(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') ||
+		r == '_' || r == '+' || r == '-'
+}
+
+// isEmojiText checks that the text only consists of emoji
+// and not of letters, digits or punctuation (e.g. an alt text "smiling face").
+func isEmojiText(text string) bool {
+	if text == "" {
+		return false
+	}
+	if _, ok := lookupShortcode(text); ok {
+		return true
+	}
+
+	for _, r := range text {
+		if r < 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCodepoints returns "😄" for a filename like "1f604.png"
+// (e.g. of twemoji or the emoji images of GitHub and WordPress).
+func parseCodepoints(src string) (string, bool) {
+	name := path.Base(src)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	if name == "" || name == "." || name == "/" {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, part := range strings.Split(name, "-") {
+		codepoint, err := strconv.ParseUint(part, 16, 32)
+		if err != nil || codepoint < 0xa9 || codepoint > unicode.MaxRune {
+			return "", false
+		}
+		b.WriteRune(rune(codepoint))
+	}
+
+	text := b.String()
+	if _, ok := lookupShortcode(text); !ok {
+		return "", false
+	}
+	return text, true
+}
+
+func hasEmojiClass(n *html.Node) bool {
+	for _, class := range strings.Fields(dom.GetAttributeOr(n, "class", "")) {
+		class = strings.ToLower(class)
+		if strings.Contains(class, "emoji") || class == "wp-smiley" || class == "emoticon" {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmojiImage checks the class (e.g. "emoji" or "wp-smiley")
+// and the url of the image.
+func isEmojiImage(n *html.Node) bool {
+	if hasEmojiClass(n) || dom.GetAttributeOr(n, "data-stringify-type", "") == "emoji" {
+		return true
+	}
+	if n.Parent != nil && dom.NodeName(n.Parent) == "span" && hasEmojiClass(n.Parent) {
+		// e.g. `<span class="c-emoji"><img /></span>` of Slack
+		return true
+	}
+
+	src := strings.ToLower(dom.GetAttributeOr(n, "src", ""))
+	return strings.Contains(src, "/emoji/") || strings.Contains(src, "twemoji")
+}
+
+func detectImageEmoji(n *html.Node) (emoji, bool) {
+	for _, key := range []string{"alt", "title", "data-emoji", "data-stringify-emoji"} {
+		val := strings.TrimSpace(dom.GetAttributeOr(n, key, ""))
+
+		if name, ok := parseShortcode(val); ok {
+			return emoji{shortcode: name}, true
+		}
+		if isEmojiText(val) {
+			return emoji{unicode: val}, true
+		}
+	}
+
+	if text, ok := parseCodepoints(dom.GetAttributeOr(n, "src", "")); ok {
+		return emoji{unicode: text}, true
+	}
+	return emoji{}, false
+}
+
+func (p *emojiPlugin) renderImage(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	if !isEmojiImage(n) {
+		return converter.RenderTryNext
+	}
+
+	e, ok := detectImageEmoji(n)
+	if !ok {
+		// Then it stays an image
+		return converter.RenderTryNext
+	}
+
+	p.write(w, e)
+	return converter.RenderSuccess
+}
+
+// handlePreRender adds the emoji as the content of an empty `<g-emoji alias>`.
+// Otherwise the whitespace around it would be collapsed.
+func (p *emojiPlugin) handlePreRender(ctx converter.Context, doc *html.Node) {
+	for _, node := range dom.AllNodes(doc) {
+		if dom.NodeName(node) != "g-emoji" || strings.TrimSpace(dom.CollectText(node)) != "" {
+			continue
+		}
+
+		text, ok := lookupEmoji(dom.GetAttributeOr(node, "alias", ""))
+		if !ok {
+			continue
+		}
+		node.AppendChild(&html.Node{
+			Type: html.TextNode,
+			Data: text,
+		})
+	}
+}
+
+func (p *emojiPlugin) renderGEmoji(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	e := emoji{
+		unicode: strings.TrimSpace(dom.CollectText(n)),
+	}
+	if name, ok := parseShortcode(":" + dom.GetAttributeOr(n, "alias", "") + ":"); ok {
+		e.shortcode = name
+	}
+	if !isEmojiText(e.unicode) {
+		e.unicode = ""
+	}
+	if e.unicode == "" && e.shortcode == "" {
+		return converter.RenderTryNext
+	}
+
+	p.write(w, e)
+	return converter.RenderSuccess
+}
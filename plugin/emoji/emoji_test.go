@@ -0,0 +1,172 @@
+package emoji
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/tester"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func TestNewEmojiPlugin(t *testing.T) {
+	runs := []struct {
+		desc     string
+		options  []option
+		input    string
+		expected string
+	}{
+		// - - - - - - - - - - unicode - - - - - - - - - - //
+		{
+			desc:     "image with emoji alt",
+			input:    `<p>Nice <img class="emoji" alt="😄" src="https://github.githubassets.com/images/icons/emoji/unicode/1f604.png"></p>`,
+			expected: `Nice 😄`,
+		},
+		{
+			desc:     "image with shortcode alt",
+			input:    `<p>Nice <img class="emoji" alt=":+1:" src="/emoji/thumbs.png"></p>`,
+			expected: `Nice 👍`,
+		},
+		{
+			desc:     "image of wordpress",
+			input:    `<p>Nice <img src="https://s.w.org/images/core/emoji/15.0.3/svg/2764.svg" alt="❤" class="wp-smiley" /></p>`,
+			expected: `Nice ❤`,
+		},
+		{
+			desc:     "image with codepoints in the filename",
+			input:    `<p>Nice <img class="emoji" alt="heart" src="https://twemoji.maxcdn.com/v/latest/72x72/2764-fe0f.png"></p>`,
+			expected: `Nice ❤️`,
+		},
+		{
+			desc:     "image with unknown custom emoji",
+			input:    `<p>Nice <img class="emoji" alt=":party_parrot:" src="/custom/party_parrot.gif"></p>`,
+			expected: `Nice :party_parrot:`,
+		},
+		{
+			desc:     "image that is not an emoji",
+			input:    `<p><img alt="😄" src="/smile.png"> <img class="emoji" alt="a smile" src="/smile.png"></p>`,
+			expected: `![😄](/smile.png) ![a smile](/smile.png)`,
+		},
+		{
+			desc:     "g-emoji",
+			input:    `<p>Nice <g-emoji class="g-emoji" alias="tada" fallback-src="https://github.githubassets.com/images/icons/emoji/unicode/1f389.png">🎉</g-emoji></p>`,
+			expected: `Nice 🎉`,
+		},
+		{
+			desc:     "g-emoji with only alias",
+			input:    `<p>A <g-emoji alias="tada"></g-emoji> party</p>`,
+			expected: `A 🎉 party`,
+		},
+
+		// - - - - - - - - - - shortcode - - - - - - - - - - //
+		{
+			desc: "shortcode",
+			options: []option{
+				WithEmojiStyle(EmojiStyleShortcode),
+			},
+			input:    `<p><img class="emoji" alt="😄"> <img class="emoji" alt="❤"> <img class="emoji" alt=":thumbsup:"> <g-emoji alias="tada">🎉</g-emoji></p>`,
+			expected: `:smile: :heart: :thumbsup: :tada:`,
+		},
+		{
+			desc: "shortcode without a known shortcode",
+			options: []option{
+				WithEmojiStyle(EmojiStyleShortcode),
+			},
+			input:    `<p><img class="emoji" alt="🫨🫨"></p>`,
+			expected: `🫨🫨`,
+		},
+		{
+			desc: "escape shortcodes in text",
+			options: []option{
+				WithEmojiStyle(EmojiStyleShortcode),
+			},
+			input:    `<p>Type :smile: or :not_an_emoji: at 10:30:00</p>`,
+			expected: `Type \:smile: or :not\_an\_emoji: at 10:30:00`,
+		},
+		{
+			desc:     "no escaping of shortcodes in text for unicode",
+			input:    `<p>Type :smile:</p>`,
+			expected: `Type :smile:`,
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewEmojiPlugin(run.options...),
+				),
+			)
+
+			out, err := conv.ConvertString(run.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, out)
+			}
+		})
+	}
+}
+
+func TestNewEmojiPlugin_InvalidStyle(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewEmojiPlugin(
+				WithEmojiStyle("image"),
+			),
+		),
+	)
+
+	_, err := conv.ConvertString("<p>text</p>")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `error while initializing "emoji" plugin: unknown value "image" for emoji style`
+	if err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if emoji, _ := lookupEmoji("smile"); emoji != "😄" {
+		t.Errorf("expected a different emoji but got %q", emoji)
+	}
+	if emoji, _ := lookupEmoji("+1"); emoji != "👍" {
+		t.Errorf("expected a different emoji but got %q", emoji)
+	}
+
+	// With and without the variation selector
+	if shortcode, _ := lookupShortcode("❤️"); shortcode != "heart" {
+		t.Errorf("expected a different shortcode but got %q", shortcode)
+	}
+	if shortcode, _ := lookupShortcode("❤"); shortcode != "heart" {
+		t.Errorf("expected a different shortcode but got %q", shortcode)
+	}
+
+	if _, ok := lookupShortcode("a"); ok {
+		t.Error("expected no shortcode")
+	}
+}
+
+func TestGoldenFiles(t *testing.T) {
+	goldenFileConvert := func(htmlInput []byte) ([]byte, error) {
+		conv := converter.NewConverter(
+			converter.WithPlugins(
+				base.NewBasePlugin(),
+				commonmark.NewCommonmarkPlugin(),
+				NewEmojiPlugin(
+					WithEmojiStyle(EmojiStyleShortcode),
+				),
+			),
+		)
+
+		return conv.ConvertReader(bytes.NewReader(htmlInput))
+	}
+
+	tester.GoldenFiles(t, goldenFileConvert, goldenFileConvert)
+}
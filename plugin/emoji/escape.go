@@ -0,0 +1,31 @@
+package emoji
+
+import (
+	"github.com/JohannesKaufmann/html-to-markdown/v2/marker"
+)
+
+// isShortcode escapes the colon of a text like ":smile:"
+// that would otherwise be rendered as an emoji.
+func isShortcode(chars []byte, index int) int {
+	if chars[index] != ':' {
+		return -1
+	}
+
+	var name []byte
+	for i := index + 1; i < len(chars); i++ {
+		if chars[i] == marker.BytesMarkerEscaping[0] {
+			continue
+		}
+		if chars[i] == ':' {
+			if _, ok := lookupEmoji(string(name)); ok {
+				return 1
+			}
+			return -1
+		}
+		if !isShortcodeChar(rune(chars[i])) {
+			return -1
+		}
+		name = append(name, chars[i])
+	}
+	return -1
+}
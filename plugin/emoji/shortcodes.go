@@ -0,0 +1,67 @@
+package emoji
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed shortcodes.txt
+var shortcodesFile string
+
+type shortcodeTable struct {
+	// emojis maps the shortcode (without colons) to the emoji
+	emojis map[string]string
+	// shortcodes maps the emoji (without variation selectors) to its first shortcode
+	shortcodes map[string]string
+}
+
+var (
+	table     shortcodeTable
+	tableOnce sync.Once
+)
+
+// withoutVariationSelector removes the U+FE0F that some
+// emojis have (e.g. "❤️") and some sources leave out (e.g. "❤").
+func withoutVariationSelector(emoji string) string {
+	return strings.ReplaceAll(emoji, "\ufe0f", "")
+}
+
+func getTable() shortcodeTable {
+	tableOnce.Do(func() {
+		table = shortcodeTable{
+			emojis:     make(map[string]string),
+			shortcodes: make(map[string]string),
+		}
+
+		for _, line := range strings.Split(shortcodesFile, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			emoji, names, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+
+			for i, name := range strings.Fields(names) {
+				table.emojis[name] = emoji
+				if i == 0 {
+					table.shortcodes[withoutVariationSelector(emoji)] = name
+				}
+			}
+		}
+	})
+	return table
+}
+
+// lookupEmoji returns the emoji for the shortcode, e.g. "😄" for "smile"
+func lookupEmoji(shortcode string) (string, bool) {
+	emoji, ok := getTable().emojis[shortcode]
+	return emoji, ok
+}
+
+// lookupShortcode returns the shortcode for the emoji, e.g. "smile" for "😄"
+func lookupShortcode(emoji string) (string, bool) {
+	shortcode, ok := getTable().shortcodes[withoutVariationSelector(emoji)]
+	return shortcode, ok
+}
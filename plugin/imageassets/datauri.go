@@ -0,0 +1,77 @@
+package imageassets
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+type dataURI struct {
+	mediaType string
+	data      []byte
+}
+
+var errNotImageDataURI = errors.New("not a data uri of an image")
+
+// parseDataURI decodes a uri in the format "data:[<mediatype>][;base64],<data>".
+func parseDataURI(rawURL string) (*dataURI, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	after, ok := cutPrefixFold(rawURL, "data:")
+	if !ok {
+		return nil, errNotImageDataURI
+	}
+	meta, content, ok := strings.Cut(after, ",")
+	if !ok {
+		return nil, errors.New("the data uri has no comma")
+	}
+
+	params := strings.Split(meta, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil, errNotImageDataURI
+	}
+
+	isBase64 := false
+	for _, param := range params[1:] {
+		if strings.EqualFold(strings.TrimSpace(param), "base64") {
+			isBase64 = true
+		}
+	}
+
+	var data []byte
+	if isBase64 {
+		// Whitespace (e.g. newlines in long attributes) is not part of the content.
+		// It could also be percent-encoded, so it is removed after decoding.
+		content, _ = url.PathUnescape(content)
+		content = strings.Join(strings.Fields(content), "")
+
+		var err error
+		data, err = base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(content, "="))
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		decoded, err := url.PathUnescape(content)
+		if err != nil {
+			return nil, err
+		}
+		data = []byte(decoded)
+	}
+
+	return &dataURI{
+		mediaType: mediaType,
+		data:      data,
+	}, nil
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
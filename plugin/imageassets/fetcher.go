@@ -0,0 +1,113 @@
+package imageassets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Fetcher loads the content of an image.
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) ([]byte, error)
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+// DefaultMaxSize is the maximum size of an image that the
+// http fetcher downloads (20 MB).
+const DefaultMaxSize = 20 << 20
+
+type httpFetcher struct {
+	client  *http.Client
+	maxSize int64
+}
+
+// NewHTTPFetcher downloads the images with the provided client. If the client
+// is nil, http.DefaultClient is used. For tests, a client with a custom
+// transport (or the client of an httptest.Server) can be passed in.
+func NewHTTPFetcher(client *http.Client) Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpFetcher{
+		client:  client,
+		maxSize: DefaultMaxSize,
+	}
+}
+
+func (f *httpFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("only http and https urls can be downloaded")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %q", res.Status)
+	}
+
+	// Read one more byte to detect images that are too large.
+	data, err := io.ReadAll(io.LimitReader(res.Body, f.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > f.maxSize {
+		return nil, fmt.Errorf("the image is larger than %d bytes", f.maxSize)
+	}
+
+	return data, nil
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+type fileFetcher struct {
+	fsys fs.FS
+}
+
+// NewFileFetcher reads the images from the file system instead of
+// downloading them. Only the path of the url is used, so both
+// "/images/cat.png" and "https://example.com/images/cat.png"
+// read the file "images/cat.png".
+//
+// This is useful for tests (e.g. with fstest.MapFS) or
+// for a website that was already saved to disk.
+func NewFileFetcher(fsys fs.FS) Fetcher {
+	return &fileFetcher{
+		fsys: fsys,
+	}
+}
+
+func (f *fileFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "data" {
+		return nil, errors.New("data uris can not be read from the file system")
+	}
+
+	name := path.Clean(strings.TrimPrefix(u.Path, "/"))
+	if !fs.ValidPath(name) || name == "." {
+		return nil, fmt.Errorf("invalid path %q", u.Path)
+	}
+
+	return fs.ReadFile(f.fsys, name)
+}
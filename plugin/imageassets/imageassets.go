@@ -0,0 +1,335 @@
+package imageassets
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+type option func(p *imageAssetsPlugin) error
+
+// WithFetcher configures how the images are loaded.
+// By default they are downloaded with http.DefaultClient.
+func WithFetcher(fetcher Fetcher) option {
+	return func(p *imageAssetsPlugin) error {
+		if fetcher == nil {
+			return errors.New("the fetcher can not be nil")
+		}
+		p.fetcher = fetcher
+		return nil
+	}
+}
+
+// WithSink configures where the images are saved (e.g. with `NewDirectorySink`).
+func WithSink(sink Sink) option {
+	return func(p *imageAssetsPlugin) error {
+		if sink == nil {
+			return errors.New("the sink can not be nil")
+		}
+		p.sink = sink
+		return nil
+	}
+}
+
+// WithAssetsPath configures the path that is used in the markdown
+// to reference the images. It should be relative to the markdown file,
+// for example "assets" results in "![](assets/a1b2c3.png)".
+func WithAssetsPath(assetsPath string) option {
+	return func(p *imageAssetsPlugin) error {
+		p.assetsPath = assetsPath
+		return nil
+	}
+}
+
+// WithRemoteImages configures whether images with a url (e.g. "https://...")
+// are fetched and saved. When false, only data uris are handled
+// (see `WithDataURIBehavior`). The default is true.
+func WithRemoteImages(fetch bool) option {
+	return func(p *imageAssetsPlugin) error {
+		p.fetchRemoteImages = fetch
+		return nil
+	}
+}
+
+type DataURIBehavior string
+
+const (
+	// DataURIBehaviorKeep keeps the data uri inside the markdown (default).
+	DataURIBehaviorKeep DataURIBehavior = "keep"
+	// DataURIBehaviorExtract decodes the image and saves it into the sink,
+	// just like the other images.
+	DataURIBehaviorExtract DataURIBehavior = "extract"
+	// DataURIBehaviorAltText removes the image and only keeps the alt text.
+	DataURIBehaviorAltText DataURIBehavior = "alt-text"
+)
+
+// WithDataURIBehavior configures what happens with "data:image/*" sources,
+// which can otherwise put large base64 blobs into the markdown.
+func WithDataURIBehavior(behavior DataURIBehavior) option {
+	return func(p *imageAssetsPlugin) error {
+		switch behavior {
+		case "":
+			return nil
+
+		case DataURIBehaviorKeep, DataURIBehaviorExtract, DataURIBehaviorAltText:
+			p.dataURIBehavior = behavior
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for data uri behavior", behavior)
+		}
+	}
+}
+
+// WithDataURIThreshold configures the size (in decoded bytes) from which
+// the `DataURIBehavior` is applied. Smaller images (e.g. icons) stay inside
+// the markdown. The default of 0 applies it to every data uri.
+func WithDataURIThreshold(size int) option {
+	return func(p *imageAssetsPlugin) error {
+		if size < 0 {
+			return fmt.Errorf("the data uri threshold can not be negative but got %d", size)
+		}
+		p.dataURIThreshold = size
+		return nil
+	}
+}
+
+type imageAssetsPlugin struct {
+	m   sync.RWMutex
+	err error
+
+	fetcher    Fetcher
+	sink       Sink
+	assetsPath string
+
+	fetchRemoteImages bool
+	dataURIBehavior   DataURIBehavior
+	dataURIThreshold  int
+
+	// filenames caches the filename for every url,
+	// so that every image is only fetched once.
+	filenames map[string]string
+}
+
+func (p *imageAssetsPlugin) setError(err error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.err = err
+}
+func (p *imageAssetsPlugin) getError() error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	return p.err
+}
+
+// NewImageAssetsPlugin saves the images of the document (e.g. into an assets folder)
+// and changes the `src` to point to the local files. Images with the same content
+// are only saved once.
+//
+// With `WithDataURIBehavior` the inline "data:image/*" sources
+// can also be extracted into the sink or be replaced by their alt text.
+//
+// If an image can not be fetched, the original url is kept and a warning
+// is reported (see `converter.WithWarningHandler`).
+func NewImageAssetsPlugin(opts ...option) converter.Plugin {
+	plugin := &imageAssetsPlugin{
+		fetcher:   NewHTTPFetcher(nil),
+		filenames: make(map[string]string),
+
+		fetchRemoteImages: true,
+		dataURIBehavior:   DataURIBehaviorKeep,
+	}
+	for _, opt := range opts {
+		err := opt(plugin)
+		if err != nil {
+			plugin.setError(err)
+			break
+		}
+	}
+	return plugin
+}
+
+func (p *imageAssetsPlugin) Name() string {
+	return "imageassets"
+}
+
+func (p *imageAssetsPlugin) Init(conv *converter.Converter) error {
+	if err := p.getError(); err != nil {
+		// Any error raised from the option func
+		return err
+	}
+	needsSink := p.fetchRemoteImages || p.dataURIBehavior == DataURIBehaviorExtract
+	if needsSink && p.sink == nil {
+		return errors.New("no sink configured, use WithSink to specify where the images are saved")
+	}
+
+	if p.dataURIBehavior == DataURIBehaviorAltText {
+		conv.Register.PreRenderer(p.handlePreRender, converter.PriorityStandard)
+	}
+	conv.Register.URLRewriter(p.handleURLRewrite, converter.PriorityStandard)
+
+	return nil
+}
+
+// isLargeDataURI checks whether the src is an image data uri
+// that is at least as large as the threshold.
+func (p *imageAssetsPlugin) isLargeDataURI(src string) (*dataURI, bool) {
+	uri, err := parseDataURI(src)
+	if err != nil {
+		return nil, false
+	}
+	return uri, len(uri.data) >= p.dataURIThreshold
+}
+
+func (p *imageAssetsPlugin) handlePreRender(ctx converter.Context, doc *html.Node) {
+	for _, node := range dom.AllNodes(doc) {
+		if dom.NodeName(node) != "img" {
+			continue
+		}
+		src := dom.GetAttributeOr(node, "src", "")
+		if _, ok := p.isLargeDataURI(src); !ok {
+			continue
+		}
+
+		alt := strings.TrimSpace(dom.GetAttributeOr(node, "alt", ""))
+		if alt == "" {
+			dom.RemoveNode(node)
+			continue
+		}
+		dom.ReplaceNode(node, &html.Node{
+			Type: html.TextNode,
+			Data: alt,
+		})
+	}
+}
+
+func (p *imageAssetsPlugin) handleURLRewrite(ctx converter.Context, info converter.URLInfo) (string, bool) {
+	if info.TagName != "img" || info.URL == "" {
+		return info.URL, true
+	}
+
+	if _, isDataURI := cutPrefixFold(info.URL, "data:"); isDataURI {
+		if p.dataURIBehavior != DataURIBehaviorExtract {
+			return info.URL, true
+		}
+		// Like for the fetching, the url of earlier url rewriters is used.
+		uri, ok := p.isLargeDataURI(info.URL)
+		if !ok {
+			// Not an image or too small, so it can stay inline.
+			return info.URL, true
+		}
+
+		filename, err := p.saveData(uri.data, contentTypeExtensions[uri.mediaType])
+		if err != nil {
+			converter.AddWarning(ctx, fmt.Errorf("could not save the data uri image: %w", err))
+			return info.URL, true
+		}
+		return path.Join(p.assetsPath, filename), true
+	}
+
+	if !p.fetchRemoteImages {
+		return info.URL, true
+	}
+
+	filename, err := p.saveImage(ctx, info.URL)
+	if err != nil {
+		converter.AddWarning(ctx, fmt.Errorf("could not save the image %q: %w", info.URL, err))
+		return info.URL, true
+	}
+
+	return path.Join(p.assetsPath, filename), true
+}
+
+func (p *imageAssetsPlugin) saveImage(ctx converter.Context, rawURL string) (string, error) {
+	p.m.RLock()
+	filename, ok := p.filenames[rawURL]
+	p.m.RUnlock()
+	if ok {
+		return filename, nil
+	}
+
+	data, err := p.fetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	filename, err = p.saveData(data, imageExtension(rawURL, data))
+	if err != nil {
+		return "", err
+	}
+
+	p.m.Lock()
+	p.filenames[rawURL] = filename
+	p.m.Unlock()
+
+	return filename, nil
+}
+
+func (p *imageAssetsPlugin) saveData(data []byte, ext string) (string, error) {
+	filename := hashFilename(data) + ext
+
+	err := p.sink.Save(filename, data)
+	if err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+func hashFilename(data []byte) string {
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf("%x", hash[:8])
+}
+
+var imageExtensions = map[string]string{
+	".png":  ".png",
+	".jpg":  ".jpg",
+	".jpeg": ".jpg",
+	".gif":  ".gif",
+	".webp": ".webp",
+	".svg":  ".svg",
+	".avif": ".avif",
+	".bmp":  ".bmp",
+	".ico":  ".ico",
+}
+
+var contentTypeExtensions = map[string]string{
+	"image/png":                ".png",
+	"image/jpeg":               ".jpg",
+	"image/gif":                ".gif",
+	"image/webp":               ".webp",
+	"image/avif":               ".avif",
+	"image/bmp":                ".bmp",
+	"image/x-icon":             ".ico",
+	"image/vnd.microsoft.icon": ".ico",
+	"image/svg+xml":            ".svg",
+}
+
+// imageExtension uses the extension of the url, and otherwise
+// tries to detect the type based on the content.
+func imageExtension(rawURL string, data []byte) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		ext := strings.ToLower(path.Ext(u.Path))
+		if val, ok := imageExtensions[ext]; ok {
+			return val
+		}
+	}
+
+	contentType := http.DetectContentType(data)
+	if ext, ok := contentTypeExtensions[contentType]; ok {
+		return ext
+	}
+	return ""
+}
@@ -0,0 +1,404 @@
+package imageassets_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/imageassets"
+)
+
+// pngData is the start of a png file, which is enough to detect the type.
+var pngData = "\x89PNG\r\n\x1a\n" + "cat"
+
+type testSink struct {
+	m     sync.Mutex
+	files map[string]string
+}
+
+func (s *testSink) Save(filename string, data []byte) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.files[filename] = string(data)
+	return nil
+}
+
+func TestNewImageAssetsPlugin(t *testing.T) {
+	fsys := fstest.MapFS{
+		"images/cat.png":      {Data: []byte(pngData)},
+		"images/cat-2x.png":   {Data: []byte(pngData)},
+		"images/dog.jpeg":     {Data: []byte("dog")},
+		"images/no-extension": {Data: []byte(pngData)},
+	}
+
+	runs := []struct {
+		desc  string
+		input string
+
+		expected         string
+		expectedFiles    []string
+		expectedWarnings []string
+	}{
+		{
+			desc:  "simple",
+			input: `<img src="/images/cat.png" alt="cat" />`,
+
+			expected:      `![cat](assets/c37c5842009839f1.png)`,
+			expectedFiles: []string{"c37c5842009839f1.png"},
+		},
+		{
+			desc:  "relative to the domain",
+			input: `<img src="dog.jpeg" />`,
+
+			expected:      `![](assets/cd6357efdd966de8.jpg)`,
+			expectedFiles: []string{"cd6357efdd966de8.jpg"},
+		},
+		{
+			desc:  "same content is only saved once",
+			input: `<img src="/images/cat.png" /> <img src="/images/cat-2x.png" /> <img src="/images/cat.png" />`,
+
+			expected:      `![](assets/c37c5842009839f1.png) ![](assets/c37c5842009839f1.png) ![](assets/c37c5842009839f1.png)`,
+			expectedFiles: []string{"c37c5842009839f1.png"},
+		},
+		{
+			desc:  "extension from the content",
+			input: `<img src="/images/no-extension" />`,
+
+			expected:      `![](assets/c37c5842009839f1.png)`,
+			expectedFiles: []string{"c37c5842009839f1.png"},
+		},
+		{
+			desc:  "links are not changed",
+			input: `<a href="/images/cat.png">cat</a>`,
+
+			expected: `[cat](https://example.com/images/cat.png)`,
+		},
+		{
+			desc:  "data uri",
+			input: `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" />`,
+
+			expected: `![](data:image/gif;base64,R0lGODlhAQABAAAAACw=)`,
+		},
+		{
+			desc:  "missing image",
+			input: `<img src="/images/missing.png" alt="missing" />`,
+
+			expected:         `![missing](https://example.com/images/missing.png)`,
+			expectedWarnings: []string{`could not save the image "https://example.com/images/missing.png": open images/missing.png: file does not exist`},
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			sink := &testSink{files: make(map[string]string)}
+
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					imageassets.NewImageAssetsPlugin(
+						imageassets.WithFetcher(imageassets.NewFileFetcher(fsys)),
+						imageassets.WithSink(sink),
+						imageassets.WithAssetsPath("assets"),
+					),
+				),
+			)
+
+			var warnings []string
+			out, err := conv.ConvertString(run.input,
+				converter.WithDomain("https://example.com/images/"),
+				converter.WithWarningHandler(func(err error) {
+					warnings = append(warnings, err.Error())
+				}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, out)
+			}
+
+			var files []string
+			for filename := range sink.files {
+				files = append(files, filename)
+			}
+			sort.Strings(files)
+			if !reflect.DeepEqual(files, run.expectedFiles) {
+				t.Errorf("expected files %v but got %v", run.expectedFiles, files)
+			}
+			if !reflect.DeepEqual(warnings, run.expectedWarnings) {
+				t.Errorf("expected warnings %q but got %q", run.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestNewImageAssetsPlugin_HTTP(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/cat.png":
+			w.Write([]byte(pngData))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			imageassets.NewImageAssetsPlugin(
+				imageassets.WithFetcher(imageassets.NewHTTPFetcher(srv.Client())),
+				imageassets.WithSink(imageassets.NewDirectorySink(filepath.Join(dir, "assets"))),
+				imageassets.WithAssetsPath("assets"),
+			),
+		),
+	)
+
+	var warnings []error
+	input := `<img src="/cat.png" /> <img src="/cat.png" /> <img src="/dog.png" />`
+	out, err := conv.ConvertString(input,
+		converter.WithDomain(srv.URL),
+		converter.WithWarningHandler(func(err error) {
+			warnings = append(warnings, err)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "![](assets/c37c5842009839f1.png) ![](assets/c37c5842009839f1.png) ![](" + srv.URL + "/dog.png)"
+	if out != expected {
+		t.Errorf("expected %q but got %q", expected, out)
+	}
+	if requests != 2 {
+		t.Errorf("expected the image to be only fetched once but got %d requests", requests)
+	}
+	if len(warnings) != 1 || warnings[0].Error() != `could not save the image "`+srv.URL+`/dog.png": unexpected status "404 Not Found"` {
+		t.Errorf("expected a warning for the missing image but got %v", warnings)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "assets", "c37c5842009839f1.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != pngData {
+		t.Errorf("expected different file content but got %q", data)
+	}
+}
+
+func TestNewImageAssetsPlugin_WithoutSink(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			imageassets.NewImageAssetsPlugin(),
+		),
+	)
+
+	_, err := conv.ConvertString(`<img src="/cat.png" />`)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `error while initializing "imageassets" plugin: no sink configured, use WithSink to specify where the images are saved`
+	if err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
+
+func TestNewImageAssetsPlugin_DataURI(t *testing.T) {
+	// "R0lGODlhAQABAAAAACw=" is a (shortened) gif with 14 bytes
+	const gifURI = "data:image/gif;base64,R0lGODlhAQABAAAAACw="
+
+	runs := []struct {
+		desc      string
+		behavior  imageassets.DataURIBehavior
+		threshold int
+		input     string
+
+		expected      string
+		expectedFiles map[string]string
+	}{
+		{
+			desc:  "keep by default",
+			input: `<img src="` + gifURI + `" alt="pixel" />`,
+
+			expected:      `![pixel](` + gifURI + `)`,
+			expectedFiles: map[string]string{},
+		},
+		{
+			desc:     "extract",
+			behavior: imageassets.DataURIBehaviorExtract,
+			input:    `<img src="` + gifURI + `" alt="pixel" />`,
+
+			expected: `![pixel](assets/2f41918f848b5fb0.gif)`,
+			expectedFiles: map[string]string{
+				"2f41918f848b5fb0.gif": "GIF89a\x01\x00\x01\x00\x00\x00\x00,",
+			},
+		},
+		{
+			desc:     "extract with uppercase scheme",
+			behavior: imageassets.DataURIBehaviorExtract,
+			input:    `<img src="DATA:image/gif;base64,R0lGODlhAQABAAAAACw=" alt="pixel" />`,
+
+			expected: `![pixel](assets/2f41918f848b5fb0.gif)`,
+			expectedFiles: map[string]string{
+				"2f41918f848b5fb0.gif": "GIF89a\x01\x00\x01\x00\x00\x00\x00,",
+			},
+		},
+		{
+			desc:     "extract with whitespace",
+			behavior: imageassets.DataURIBehaviorExtract,
+			input:    "<img src=\"data:image/gif;base64,R0lGODlh\n  AQABAAAAACw=\" alt=\"pixel\" />",
+
+			expected: `![pixel](assets/2f41918f848b5fb0.gif)`,
+			expectedFiles: map[string]string{
+				"2f41918f848b5fb0.gif": "GIF89a\x01\x00\x01\x00\x00\x00\x00,",
+			},
+		},
+		{
+			desc:     "extract without base64",
+			behavior: imageassets.DataURIBehaviorExtract,
+			input:    `<img src="data:image/svg+xml,%3Csvg xmlns='http://www.w3.org/2000/svg'/%3E" />`,
+
+			expected: `![](assets/a87cba1d08bc5397.svg)`,
+			expectedFiles: map[string]string{
+				"a87cba1d08bc5397.svg": "<svg xmlns='http://www.w3.org/2000/svg'/>",
+			},
+		},
+		{
+			desc:      "extract below the threshold",
+			behavior:  imageassets.DataURIBehaviorExtract,
+			threshold: 1024,
+			input:     `<img src="` + gifURI + `" alt="pixel" />`,
+
+			expected:      `![pixel](` + gifURI + `)`,
+			expectedFiles: map[string]string{},
+		},
+		{
+			desc:     "extract ignores other data uris",
+			behavior: imageassets.DataURIBehaviorExtract,
+			input:    `<img src="data:text/plain,hello" />`,
+
+			expected:      `![](data:text/plain,hello)`,
+			expectedFiles: map[string]string{},
+		},
+		{
+			desc:     "alt text",
+			behavior: imageassets.DataURIBehaviorAltText,
+			input:    `<p>A <img src="` + gifURI + `" alt="*tiny* pixel" /> and <img src="` + gifURI + `" /> image</p>`,
+
+			expected:      `A \*tiny* pixel and image`,
+			expectedFiles: map[string]string{},
+		},
+		{
+			desc:      "alt text below the threshold",
+			behavior:  imageassets.DataURIBehaviorAltText,
+			threshold: 15,
+			input:     `<img src="` + gifURI + `" alt="pixel" />`,
+
+			expected:      `![pixel](` + gifURI + `)`,
+			expectedFiles: map[string]string{},
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			sink := imageassets.NewMemorySink()
+
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					imageassets.NewImageAssetsPlugin(
+						imageassets.WithRemoteImages(false),
+						imageassets.WithSink(sink),
+						imageassets.WithAssetsPath("assets"),
+						imageassets.WithDataURIBehavior(run.behavior),
+						imageassets.WithDataURIThreshold(run.threshold),
+					),
+				),
+			)
+
+			out, err := conv.ConvertString(run.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, out)
+			}
+
+			files := make(map[string]string)
+			for filename, data := range sink.Files() {
+				files[filename] = string(data)
+			}
+			if !reflect.DeepEqual(files, run.expectedFiles) {
+				t.Errorf("expected files %q but got %q", run.expectedFiles, files)
+			}
+		})
+	}
+}
+
+func TestNewImageAssetsPlugin_DataURI_URLRewriter(t *testing.T) {
+	sink := imageassets.NewMemorySink()
+
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			imageassets.NewImageAssetsPlugin(
+				imageassets.WithRemoteImages(false),
+				imageassets.WithSink(sink),
+				imageassets.WithAssetsPath("assets"),
+				imageassets.WithDataURIBehavior(imageassets.DataURIBehaviorExtract),
+			),
+		),
+	)
+	// An earlier url rewriter replaces the data uri
+	conv.Register.URLRewriter(func(ctx converter.Context, info converter.URLInfo) (string, bool) {
+		return "data:image/gif;base64,R0lGODlhAQABAAAAACw=", true
+	}, converter.PriorityEarly)
+
+	out, err := conv.ConvertString(`<img src="data:image/png;base64,iVBORw0KGgo=" alt="pixel" />`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `![pixel](assets/2f41918f848b5fb0.gif)`
+	if out != expected {
+		t.Errorf("expected %q but got %q", expected, out)
+	}
+	if len(sink.Files()) != 1 {
+		t.Errorf("expected 1 file but got %d", len(sink.Files()))
+	}
+}
+
+func TestWithDataURIBehavior_Invalid(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			imageassets.NewImageAssetsPlugin(
+				imageassets.WithDataURIBehavior("remove"),
+			),
+		),
+	)
+
+	_, err := conv.ConvertString(`<img src="/cat.png" />`)
+	expected := `error while initializing "imageassets" plugin: unknown value "remove" for data uri behavior`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q but got %v", expected, err)
+	}
+}
@@ -0,0 +1,88 @@
+package imageassets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sink stores the image files.
+type Sink interface {
+	// Save stores the data under the filename. Since the filename is
+	// based on the content hash, an existing file with the same name
+	// already has the same content.
+	Save(filename string, data []byte) error
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+type directorySink struct {
+	dir string
+}
+
+// NewDirectorySink saves the images into the directory,
+// which is created if it does not exist yet.
+func NewDirectorySink(dir string) Sink {
+	return &directorySink{
+		dir: dir,
+	}
+}
+
+func (s *directorySink) Save(filename string, data []byte) error {
+	err := os.MkdirAll(s.dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, filename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		// Same filename means same content, so there is nothing to do.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// - - - - - - - - - - - - - - - - - - - - - //
+
+// MemorySink keeps the images in memory, so that the
+// caller can decide what to do with them.
+type MemorySink struct {
+	m     sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemorySink creates a sink that keeps the images in a map.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{
+		files: make(map[string][]byte),
+	}
+}
+
+func (s *MemorySink) Save(filename string, data []byte) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.files[filename] = data
+	return nil
+}
+
+// Files returns a copy of the map from filename to content.
+func (s *MemorySink) Files() map[string][]byte {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	files := make(map[string][]byte, len(s.files))
+	for filename, data := range s.files {
+		files[filename] = data
+	}
+	return files
+}
@@ -0,0 +1,75 @@
+package semantic
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+const stateKeyAbbreviations = "semantic_abbreviations"
+
+type abbreviation struct {
+	text  string
+	title string
+}
+
+func (p *semanticPlugin) renderAbbr(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	ctx.RenderChildNodes(ctx, w, n)
+
+	title := collapseWhitespace(dom.GetAttributeOr(n, "title", ""))
+	text := collapseWhitespace(dom.CollectText(n))
+	if title == "" || text == "" {
+		return converter.RenderSuccess
+	}
+
+	switch p.abbreviationStyle {
+	case AbbreviationStyleExpand:
+		if !strings.EqualFold(title, text) {
+			w.WriteString(" (")
+			w.Write(ctx.EscapeContent([]byte(title)))
+			w.WriteString(")")
+		}
+
+	case AbbreviationStyleDefinition:
+		if strings.ContainsAny(text, "[]") {
+			// The text can not be part of the definition
+			return converter.RenderSuccess
+		}
+
+		converter.UpdateState(ctx, stateKeyAbbreviations, func(abbrs []abbreviation) []abbreviation {
+			for _, abbr := range abbrs {
+				if abbr.text == text {
+					// The first definition wins, since the
+					// definition applies to the whole document.
+					return abbrs
+				}
+			}
+			return append(abbrs, abbreviation{text: text, title: title})
+		})
+	}
+
+	return converter.RenderSuccess
+}
+
+func (p *semanticPlugin) handlePostRender(ctx converter.Context, result []byte) []byte {
+	abbrs := converter.GetState[[]abbreviation](ctx, stateKeyAbbreviations)
+	if len(abbrs) == 0 {
+		return result
+	}
+
+	var buf bytes.Buffer
+	buf.Write(result)
+	if len(result) != 0 {
+		buf.WriteString("\n\n")
+	}
+	for i, abbr := range abbrs {
+		if i != 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("*[" + abbr.text + "]: " + abbr.title)
+	}
+	return buf.Bytes()
+}
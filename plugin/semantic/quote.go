@@ -0,0 +1,96 @@
+package semantic
+
+import (
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+type quotationMarks struct {
+	open, close             string
+	nestedOpen, nestedClose string
+}
+
+var englishQuotationMarks = quotationMarks{"“", "”", "‘", "’"}
+
+// languageQuotationMarks maps the primary language subtag
+// (e.g. "de" for "de-AT") to its quotation marks.
+var languageQuotationMarks = map[string]quotationMarks{
+	"en": englishQuotationMarks,
+	"nl": englishQuotationMarks,
+	"pt": {"“", "”", "‘", "’"},
+	"de": {"„", "“", "‚", "‘"},
+	"cs": {"„", "“", "‚", "‘"},
+	"sk": {"„", "“", "‚", "‘"},
+	"pl": {"„", "”", "«", "»"},
+	"fr": {"«\u00a0", "\u00a0»", "“", "”"},
+	"es": {"«", "»", "“", "”"},
+	"it": {"«", "»", "“", "”"},
+	"ru": {"«", "»", "„", "“"},
+	"uk": {"«", "»", "„", "“"},
+	"sv": {"”", "”", "’", "’"},
+	"fi": {"”", "”", "’", "’"},
+	"da": {"»", "«", "›", "‹"},
+	"ja": {"「", "」", "『", "』"},
+	"zh": {"“", "”", "‘", "’"},
+}
+
+var straightQuotationMarks = quotationMarks{`"`, `"`, `'`, `'`}
+
+// getLanguage returns the primary language subtag of the
+// closest `lang` attribute, e.g. "de" for `lang="de-AT"`
+func getLanguage(n *html.Node) string {
+	for node := n; node != nil; node = node.Parent {
+		if node.Type != html.ElementNode {
+			continue
+		}
+		lang, ok := dom.GetAttribute(node, "lang")
+		if !ok {
+			continue
+		}
+
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		primary, _, _ := strings.Cut(lang, "-")
+		return primary
+	}
+	return ""
+}
+
+// isNestedQuote reports whether there is an odd number of <q> ancestors.
+func isNestedQuote(n *html.Node) bool {
+	nested := false
+	for node := n.Parent; node != nil; node = node.Parent {
+		if dom.NodeName(node) == "q" {
+			nested = !nested
+		}
+	}
+	return nested
+}
+
+func (p *semanticPlugin) getQuotationMarks(n *html.Node) (string, string) {
+	marks := straightQuotationMarks
+	if p.quoteStyle == QuoteStyleCurly {
+		var ok bool
+		marks, ok = languageQuotationMarks[getLanguage(n)]
+		if !ok {
+			marks = englishQuotationMarks
+		}
+	}
+
+	if isNestedQuote(n) {
+		return marks.nestedOpen, marks.nestedClose
+	}
+	return marks.open, marks.close
+}
+
+func (p *semanticPlugin) renderQuote(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	open, close := p.getQuotationMarks(n)
+
+	w.WriteString(open)
+	ctx.RenderChildNodes(ctx, w, n)
+	w.WriteString(close)
+
+	return converter.RenderSuccess
+}
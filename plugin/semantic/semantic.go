@@ -0,0 +1,185 @@
+package semantic
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+type option func(p *semanticPlugin) error
+
+type AbbreviationStyle string
+
+const (
+	// AbbreviationStyleDefinition keeps the text and adds an abbreviation
+	// definition of Markdown Extra (e.g. `*[HTML]: Hyper Text Markup Language`)
+	// to the end of the document (default).
+	AbbreviationStyleDefinition AbbreviationStyle = "definition"
+	// AbbreviationStyleExpand adds the title in parentheses after the text,
+	// e.g. "HTML (Hyper Text Markup Language)"
+	AbbreviationStyleExpand AbbreviationStyle = "expand"
+	// AbbreviationStyleText only keeps the text.
+	AbbreviationStyleText AbbreviationStyle = "text"
+)
+
+type QuoteStyle string
+
+const (
+	// QuoteStyleCurly uses the typographic quotation marks of the language
+	// (from the `lang` attribute), e.g. “English” or „Deutsch“ (default).
+	QuoteStyleCurly QuoteStyle = "curly"
+	// QuoteStyleStraight uses the ascii quotation marks, e.g. "quote"
+	QuoteStyleStraight QuoteStyle = "straight"
+)
+
+// WithAbbreviationStyle configures how `<abbr title>` is rendered.
+//
+//	"definition", "expand" or "text"
+func WithAbbreviationStyle(style AbbreviationStyle) option {
+	return func(p *semanticPlugin) error {
+		switch style {
+		case AbbreviationStyleDefinition, AbbreviationStyleExpand, AbbreviationStyleText:
+			p.abbreviationStyle = style
+			return nil
+		default:
+			return fmt.Errorf("unknown value %q for abbreviation style", style)
+		}
+	}
+}
+
+// WithQuoteStyle configures which quotation marks are used for `<q>`.
+//
+//	"curly" or "straight"
+func WithQuoteStyle(style QuoteStyle) option {
+	return func(p *semanticPlugin) error {
+		switch style {
+		case QuoteStyleCurly, QuoteStyleStraight:
+			p.quoteStyle = style
+			return nil
+		default:
+			return fmt.Errorf("unknown value %q for quote style", style)
+		}
+	}
+}
+
+// WithTimeDatetime appends the `datetime` attribute of `<time>`
+// in parentheses, e.g. "yesterday (2024-01-02)"
+func WithTimeDatetime(enabled bool) option {
+	return func(p *semanticPlugin) error {
+		p.appendDatetime = enabled
+		return nil
+	}
+}
+
+type semanticPlugin struct {
+	m   sync.RWMutex
+	err error
+
+	abbreviationStyle AbbreviationStyle
+	quoteStyle        QuoteStyle
+	appendDatetime    bool
+}
+
+func (p *semanticPlugin) setError(err error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.err = err
+}
+func (p *semanticPlugin) getError() error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	return p.err
+}
+
+// NewSemanticPlugin converts the semantic inline elements
+// `<abbr>`, `<q>`, `<cite>`, `<dfn>` and `<time>`.
+//
+// Without this plugin only their text is kept.
+func NewSemanticPlugin(opts ...option) converter.Plugin {
+	plugin := &semanticPlugin{
+		abbreviationStyle: AbbreviationStyleDefinition,
+		quoteStyle:        QuoteStyleCurly,
+	}
+	for _, opt := range opts {
+		err := opt(plugin)
+		if err != nil {
+			plugin.setError(err)
+			break
+		}
+	}
+	return plugin
+}
+
+func (p *semanticPlugin) Name() string {
+	return "semantic"
+}
+
+func (p *semanticPlugin) Init(conv *converter.Converter) error {
+	if err := p.getError(); err != nil {
+		// Any error raised from the option func
+		return err
+	}
+
+	// Note: The priority is high, so that the <em> elements
+	// are merged by the pre-render function of the commonmark plugin.
+	conv.Register.PreRenderer(p.handlePreRender, converter.PriorityEarly)
+
+	conv.Register.RendererFor("abbr", converter.TagTypeInline, p.renderAbbr, converter.PriorityStandard)
+	conv.Register.RendererFor("q", converter.TagTypeInline, p.renderQuote, converter.PriorityStandard)
+	conv.Register.RendererFor("time", converter.TagTypeInline, p.renderTime, converter.PriorityStandard)
+
+	// Note: The priority is low, so that the definitions are
+	// added _after_ the content was trimmed and unescaped.
+	conv.Register.PostRenderer(p.handlePostRender, converter.PriorityLate)
+
+	return nil
+}
+
+// handlePreRender renames `<cite>` and `<dfn>` to `<em>` so that
+// they are rendered as italic with the configured delimiter.
+func (p *semanticPlugin) handlePreRender(ctx converter.Context, doc *html.Node) {
+	var finder func(node *html.Node)
+	finder = func(node *html.Node) {
+		name := dom.NodeName(node)
+		if name == "cite" || name == "dfn" {
+			if tagType, _ := ctx.GetTagType(name); tagType != converter.TagTypeKeep {
+				node.Data = "em"
+				node.DataAtom = 0
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			finder(child)
+		}
+	}
+	finder(doc)
+}
+
+// collapseWhitespace replaces all whitespace with a single space.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (p *semanticPlugin) renderTime(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	ctx.RenderChildNodes(ctx, w, n)
+
+	if !p.appendDatetime {
+		return converter.RenderSuccess
+	}
+
+	datetime := collapseWhitespace(dom.GetAttributeOr(n, "datetime", ""))
+	if datetime == "" || datetime == collapseWhitespace(dom.CollectText(n)) {
+		return converter.RenderSuccess
+	}
+
+	w.WriteString(" (")
+	w.Write(ctx.EscapeContent([]byte(datetime)))
+	w.WriteString(")")
+	return converter.RenderSuccess
+}
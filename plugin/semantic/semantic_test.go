@@ -0,0 +1,181 @@
+package semantic
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/tester"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func TestNewSemanticPlugin(t *testing.T) {
+	runs := []struct {
+		desc     string
+		options  []option
+		input    string
+		expected string
+	}{
+		// - - - - - - - - - - abbr - - - - - - - - - - //
+		{
+			desc:     "abbr with definition",
+			input:    `<p>The <abbr title="Hyper  Text Markup Language">HTML</abbr> spec</p>`,
+			expected: "The HTML spec\n\n*[HTML]: Hyper Text Markup Language",
+		},
+		{
+			desc: "abbr definitions in order of appearance",
+			input: `
+<p><abbr title="World Wide Web Consortium">W3C</abbr> and <abbr title="Hyper Text Markup Language">HTML</abbr></p>
+<p><abbr title="Something else">HTML</abbr> and <abbr>CSS</abbr></p>
+			`,
+			expected: "W3C and HTML\n\nHTML and CSS\n\n*[W3C]: World Wide Web Consortium\n*[HTML]: Hyper Text Markup Language",
+		},
+		{
+			desc: "abbr expanded",
+			options: []option{
+				WithAbbreviationStyle(AbbreviationStyleExpand),
+			},
+			input:    `<p>The <abbr title="Hyper Text *Markup* Language">HTML</abbr> spec</p>`,
+			expected: `The HTML (Hyper Text \*Markup* Language) spec`,
+		},
+		{
+			desc: "abbr as text",
+			options: []option{
+				WithAbbreviationStyle(AbbreviationStyleText),
+			},
+			input:    `<p>The <abbr title="Hyper Text Markup Language">HTML</abbr> spec</p>`,
+			expected: `The HTML spec`,
+		},
+
+		// - - - - - - - - - - q - - - - - - - - - - //
+		{
+			desc:     "quote",
+			input:    `<p>He said <q>hello <q>world</q></q>.</p>`,
+			expected: `He said “hello ‘world’”.`,
+		},
+		{
+			desc:     "quote with language",
+			input:    `<p lang="de-AT">Er sagte <q>hallo</q> und <q lang="fr">bonjour</q>.</p>`,
+			expected: "Er sagte „hallo“ und « bonjour ».",
+		},
+		{
+			desc:     "quote with unknown language",
+			input:    `<p lang="xx">A <q>quote</q></p>`,
+			expected: `A “quote”`,
+		},
+		{
+			desc: "quote straight",
+			options: []option{
+				WithQuoteStyle(QuoteStyleStraight),
+			},
+			input:    `<p lang="de">He said <q>hello <q>world</q></q>.</p>`,
+			expected: `He said "hello 'world'".`,
+		},
+
+		// - - - - - - - - - - cite & dfn - - - - - - - - - - //
+		{
+			desc:     "cite and dfn",
+			input:    `<p>From <cite>The Book</cite>: a <dfn>term</dfn> is a word.</p>`,
+			expected: `From *The Book*: a *term* is a word.`,
+		},
+		{
+			desc:     "cite inside of italic",
+			input:    `<p><em>Read <cite>The Book</cite></em></p>`,
+			expected: `*Read The Book*`,
+		},
+
+		// - - - - - - - - - - time - - - - - - - - - - //
+		{
+			desc:     "time",
+			input:    `<p>Published <time datetime="2024-01-02">yesterday</time></p>`,
+			expected: `Published yesterday`,
+		},
+		{
+			desc: "time with datetime",
+			options: []option{
+				WithTimeDatetime(true),
+			},
+			input:    `<p>Published <time datetime="2024-01-02">yesterday</time> and on <time datetime="2024-01-01">2024-01-01</time></p>`,
+			expected: `Published yesterday (2024-01-02) and on 2024-01-01`,
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewSemanticPlugin(run.options...),
+				),
+			)
+
+			out, err := conv.ConvertString(run.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, out)
+			}
+		})
+	}
+}
+
+func TestNewSemanticPlugin_InvalidStyle(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewSemanticPlugin(
+				WithQuoteStyle("fancy"),
+			),
+		),
+	)
+
+	_, err := conv.ConvertString("<p>text</p>")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `error while initializing "semantic" plugin: unknown value "fancy" for quote style`
+	if err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
+
+func TestNewSemanticPlugin_KeepCite(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewSemanticPlugin(),
+		),
+	)
+	conv.Register.TagType("cite", converter.TagTypeKeep, converter.PriorityEarly)
+
+	out, err := conv.ConvertString(`<p>From <cite>The Book</cite></p>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `From <cite>The Book</cite>`
+	if out != expected {
+		t.Errorf("expected %q but got %q", expected, out)
+	}
+}
+
+func TestGoldenFiles(t *testing.T) {
+	goldenFileConvert := func(htmlInput []byte) ([]byte, error) {
+		conv := converter.NewConverter(
+			converter.WithPlugins(
+				base.NewBasePlugin(),
+				commonmark.NewCommonmarkPlugin(),
+				NewSemanticPlugin(
+					WithTimeDatetime(true),
+				),
+			),
+		)
+
+		return conv.ConvertReader(bytes.NewReader(htmlInput))
+	}
+
+	tester.GoldenFiles(t, goldenFileConvert, goldenFileConvert)
+}
@@ -1,6 +1,8 @@
 package table
 
 import (
+	"slices"
+
 	"github.com/JohannesKaufmann/dom"
 	"golang.org/x/net/html"
 )
@@ -29,13 +31,77 @@ func selectHeaderRowNode(node *html.Node) *html.Node {
 
 	return nil
 }
-func selectNormalRowNodes(tableNode *html.Node, selectedHeaderRowNode *html.Node) []*html.Node {
+
+// selectExtraHeaderRowNodes returns the rows that belong to the header
+// in addition to the header row, e.g. the subheaders below a group header:
+//
+//	|    Q1    |    Q2    |
+//	| Rev | PT | Rev | PT |
+func selectExtraHeaderRowNodes(headerRowNode *html.Node) []*html.Node {
+	if headerRowNode == nil {
+		return nil
+	}
+	inThead := headerRowNode.Parent != nil && dom.NodeName(headerRowNode.Parent) == "thead"
+
+	var collected []*html.Node
+	for next := headerRowNode.NextSibling; next != nil; next = next.NextSibling {
+		if dom.NodeName(next) != "tr" {
+			continue
+		}
+		if inThead {
+			// Every row inside the "thead" is part of the header.
+			collected = append(collected, next)
+			continue
+		}
+
+		// Without a "thead" we can only look at the cells. Once a
+		// row contains data cells, the header is over.
+		if !isHeaderOnlyRowNode(next) || isSectionRowNode(next) {
+			break
+		}
+		collected = append(collected, next)
+	}
+
+	return collected
+}
+
+func selectCellNodes(rowNode *html.Node) []*html.Node {
+	return dom.FindAllNodes(rowNode, func(node *html.Node) bool {
+		name := dom.NodeName(node)
+		return name == "th" || name == "td"
+	})
+}
+
+func isHeaderOnlyRowNode(rowNode *html.Node) bool {
+	cellNodes := selectCellNodes(rowNode)
+	if len(cellNodes) == 0 {
+		return false
+	}
+	for _, cellNode := range cellNodes {
+		if dom.NodeName(cellNode) != "th" {
+			return false
+		}
+	}
+	return true
+}
+
+// isSectionRowNode reports whether the row is an intermediate heading
+// that spans over the other columns, e.g. <tr><th colspan="3">Fruits</th></tr>
+func isSectionRowNode(rowNode *html.Node) bool {
+	cellNodes := selectCellNodes(rowNode)
+	if len(cellNodes) != 1 || dom.NodeName(cellNodes[0]) != "th" {
+		return false
+	}
+	return getNumberAttributeOr(cellNodes[0], "colspan", 1) > 1
+}
+
+func selectNormalRowNodes(tableNode *html.Node, selectedHeaderRowNodes ...*html.Node) []*html.Node {
 	var collected []*html.Node
 
 	var finder func(node *html.Node)
 	finder = func(node *html.Node) {
 		name := dom.NodeName(node)
-		if name == "tr" && node != selectedHeaderRowNode {
+		if name == "tr" && !slices.Contains(selectedHeaderRowNodes, node) {
 			// We want to make sure to not select the header row a *second* time.
 			collected = append(collected, node)
 		}
@@ -2,36 +2,57 @@ package table
 
 import (
 	"bytes"
+	"slices"
+	"strings"
 
 	"github.com/JohannesKaufmann/dom"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/textutils"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/marker"
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
 )
 
 type tableContent struct {
+	Format     TableFormat
 	Alignments []string
 	Rows       [][][]byte
 	Caption    []byte
+
+	// Sections is only set for SectionRowBehaviorSplit.
+	Sections []tableSection
+}
+
+// tableSection is a part of the table that is rendered as its own table.
+type tableSection struct {
+	// Title is the content of the section row, or nil for the rows before the first section row.
+	Title []byte
+	// Rows contains the header row followed by the rows of the section.
+	Rows [][][]byte
 }
 
 func containsNewline(b []byte) bool {
 	return bytes.Contains(b, []byte("\n"))
 }
 
-func hasProblematicChildNode(node *html.Node) bool {
-	problematicNode := dom.FindFirstNode(node, func(n *html.Node) bool {
+func hasNestedTableNode(node *html.Node) bool {
+	nestedTable := dom.FindFirstNode(node, func(n *html.Node) bool {
+		return dom.NodeName(n) == "table"
+	})
+
+	return nestedTable != nil
+}
+
+// hasBlockChildNode reports whether the table contains block nodes
+// that can be represented in a grid table but not in a pipe table.
+func hasBlockChildNode(node *html.Node) bool {
+	blockNode := dom.FindFirstNode(node, func(n *html.Node) bool {
 		name := dom.NodeName(n)
 
 		if dom.NameIsHeading(name) {
 			return true
 		}
 		switch name {
-		case "table":
-			// This will be caught with the newline check anyway.
-			// But we can safe some effort by aborting early...
-			return true
 		case "hr", "ul", "ol", "blockquote":
 			return true
 		}
@@ -39,7 +60,7 @@ func hasProblematicChildNode(node *html.Node) bool {
 		return false
 	})
 
-	return problematicNode != nil
+	return blockNode != nil
 }
 
 func hasProblematicParentNode(node *html.Node) bool {
@@ -75,15 +96,27 @@ func (p *tablePlugin) collectTableContent(ctx converter.Context, node *html.Node
 			return nil
 		}
 	}
-	if hasProblematicChildNode(node) {
-		// There are certain nodes (e.g. <hr />) that cannot be in a table.
-		// If we found one, we unfortunately cannot convert the table.
-		//
-		// Note: It is okay for a block node (e.g. <div>) to be in a table.
-		//       However once it causes multiple lines, it does not work anymore.
-		//       For that we have the `containsNewline` check below.
+	format := p.tableFormat
+	if hasNestedTableNode(node) {
+		// Not even a grid table can contain another table.
+		// This would be caught with the newline check anyway.
+		// But we can safe some effort by aborting early...
 		return nil
 	}
+	if hasBlockChildNode(node) {
+		switch format {
+		case TableFormatPipe:
+			// There are certain nodes (e.g. <hr />) that cannot be in a pipe table.
+			// If we found one, we unfortunately cannot convert the table.
+			//
+			// Note: It is okay for a block node (e.g. <div>) to be in a table.
+			//       However once it causes multiple lines, it does not work anymore.
+			//       For that we have the `containsNewline` check below.
+			return nil
+		case TableFormatAuto:
+			format = TableFormatGrid
+		}
+	}
 
 	if hasProblematicParentNode(node) {
 		// There are certain parent nodes (e.g. <a>) that cannot contain a table.
@@ -92,9 +125,17 @@ func (p *tablePlugin) collectTableContent(ctx converter.Context, node *html.Node
 	}
 
 	headerRowNode := selectHeaderRowNode(node)
-	normalRowNodes := selectNormalRowNodes(node, headerRowNode)
 
-	rows := p.collectRows(ctx, headerRowNode, normalRowNodes)
+	var headerRowNodes []*html.Node
+	if headerRowNode != nil {
+		headerRowNodes = append(headerRowNodes, headerRowNode)
+	}
+	if p.mergeHeaderRows {
+		headerRowNodes = append(headerRowNodes, selectExtraHeaderRowNodes(headerRowNode)...)
+	}
+	normalRowNodes := selectNormalRowNodes(node, headerRowNodes...)
+
+	rows, sectionIndexes := p.collectRows(ctx, headerRowNodes, normalRowNodes)
 	if len(rows) == 0 {
 		return nil
 	}
@@ -102,6 +143,22 @@ func (p *tablePlugin) collectTableContent(ctx converter.Context, node *html.Node
 	for i, cells := range rows {
 		for j, cell := range cells {
 			if containsNewline(cell) {
+				if format == TableFormatAuto && p.newlineBehavior != NewlineBehaviorPreserve {
+					// The previous cells did not contain any newlines,
+					// so they can also be rendered in a grid table.
+					format = TableFormatGrid
+				}
+				if format == TableFormatGrid {
+					// The lines of a cell are just written below each other.
+					// But the cell is not yet part of the output, so the
+					// post renderers did not get the chance to clean it up.
+					cell = textutils.TrimConsecutiveNewlines(cell)
+					cell = textutils.TrimUnnecessaryHardLineBreaks(cell)
+
+					// The lines of a code block also need to be inside the borders.
+					rows[i][j] = bytes.ReplaceAll(cell, marker.BytesMarkerCodeBlockNewline, []byte("\n"))
+					continue
+				}
 				if p.newlineBehavior == NewlineBehaviorPreserve {
 					// Replace newlines with <br /> tags
 					rows[i][j] = bytes.ReplaceAll(cell, []byte("\n"), []byte("<br />"))
@@ -113,18 +170,73 @@ func (p *tablePlugin) collectTableContent(ctx converter.Context, node *html.Node
 		}
 	}
 
+	if format != TableFormatGrid {
+		format = TableFormatPipe
+	}
+
+	var sections []tableSection
+	switch p.sectionRowBehavior {
+	case SectionRowBehaviorSeparator:
+		for _, index := range sectionIndexes {
+			if title := rows[index][0]; len(title) != 0 {
+				rows[index][0] = textutils.DelimiterForEveryLine(title, []byte("**"))
+			}
+		}
+	case SectionRowBehaviorSplit:
+		sections = splitSections(rows, sectionIndexes)
+	}
+
+	alignments := p.collectAlignments(node, len(calculateMaxCounts(rows)), headerRowNode, normalRowNodes)
+	if p.numericAlignment {
+		alignments = inferNumericAlignments(alignments, rows)
+	}
+
 	return &tableContent{
-		Alignments: collectAlignments(headerRowNode, normalRowNodes),
+		Format:     format,
+		Alignments: alignments,
 		Rows:       rows,
-		Caption:    collectCaption(ctx, node),
+		Caption:    p.collectCaption(ctx, node),
+		Sections:   sections,
 	}
 }
 
+// splitSections splits the rows at the section rows. Every section gets a copy of the header row.
+func splitSections(rows [][][]byte, sectionIndexes []int) []tableSection {
+	if len(sectionIndexes) == 0 {
+		return nil
+	}
+	header := rows[0]
+
+	ends := append(slices.Clone(sectionIndexes), len(rows))
+
+	var sections []tableSection
+	start := 1
+	var title []byte
+	for _, index := range ends {
+		if index > start || title != nil {
+			body := rows[start:index]
+			sections = append(sections, tableSection{
+				Title: title,
+				Rows:  append([][][]byte{slices.Clone(header)}, body...),
+			})
+		}
+
+		if index < len(rows) {
+			title = bytes.Join(bytes.Fields(rows[index][0]), []byte(" "))
+			if title == nil {
+				title = []byte{}
+			}
+		}
+		start = index + 1
+	}
+	return sections
+}
+
 // Sometimes a cell wants to *span* over multiple columns or/and rows.
 // What should be displayed in those other cells?
 // Render exactly the same content OR an empty string?
-func (p *tablePlugin) getContentForMergedCell(originalContent []byte) []byte {
-	if p.spanCellBehavior == SpanBehaviorMirror {
+func getContentForMergedCell(behavior SpanCellBehavior, originalContent []byte) []byte {
+	if behavior == SpanBehaviorMirror {
 		return originalContent
 	}
 
@@ -141,31 +253,41 @@ func getFirstNode(node *html.Node, nodes ...*html.Node) *html.Node {
 	return nil
 }
 
-func collectAlignments(headerRowNode *html.Node, rowNodes []*html.Node) []string {
-	firstRow := getFirstNode(headerRowNode, rowNodes...)
-	if firstRow == nil {
-		return nil
-	}
+func (p *tablePlugin) collectAlignments(tableNode *html.Node, columnCount int, headerRowNode *html.Node, rowNodes []*html.Node) []string {
+	// The <col> elements specify the alignment for the whole column...
+	alignments := p.collectColumnAlignments(tableNode, columnCount)
 
-	cellNodes := dom.FindAllNodes(firstRow, func(node *html.Node) bool {
-		name := dom.NodeName(node)
-		return name == "th" || name == "td"
-	})
+	// ... but the cells can override it. Often only the cells
+	// below the header are aligned, so we look at both rows.
+	var firstRows []*html.Node
+	if headerRowNode != nil {
+		firstRows = append(firstRows, headerRowNode)
+	}
+	if len(rowNodes) > 0 {
+		firstRows = append(firstRows, rowNodes[0])
+	}
+	if len(firstRows) == 0 {
+		return alignments
+	}
 
-	var alignments []string
-	for _, cellNode := range cellNodes {
-		align := dom.GetAttributeOr(cellNode, "align", "")
+	overridden := make(map[int]bool)
+	for _, rowNode := range firstRows {
+		for index, cellNode := range selectCellNodes(rowNode) {
+			align := p.getAlignment(cellNode)
+			if align == "" || overridden[index] {
+				continue
+			}
 
-		alignments = append(alignments, align)
+			alignments = growSlice(alignments, index, "")
+			alignments[index] = align
+			overridden[index] = true
+		}
 	}
 
 	return alignments
 }
-func (p *tablePlugin) collectCellsInRow(ctx converter.Context, rowIndex int, rowNode *html.Node) ([][]byte, []modification) {
-	cellNodes := dom.FindAllNodes(rowNode, func(node *html.Node) bool {
-		name := dom.NodeName(node)
-		return name == "th" || name == "td"
-	})
+func (p *tablePlugin) collectCellsInRow(ctx converter.Context, rowIndex int, rowNode *html.Node, spanBehavior SpanCellBehavior) ([][]byte, []modification) {
+	cellNodes := selectCellNodes(rowNode)
 
 	cellContents := make([][]byte, 0, len(cellNodes))
 	modifications := make([]modification, 0)
@@ -187,32 +309,49 @@ func (p *tablePlugin) collectCellsInRow(ctx converter.Context, rowIndex int, row
 		rowSpan := getNumberAttributeOr(cellNode, "rowspan", 1)
 		colSpan := getNumberAttributeOr(cellNode, "colspan", 1)
 
-		mods := calculateModifications(rowIndex, index, rowSpan, colSpan, p.getContentForMergedCell(content))
+		mods := calculateModifications(rowIndex, index, rowSpan, colSpan, getContentForMergedCell(spanBehavior, content))
 
 		modifications = append(modifications, mods...)
 	}
 
 	return cellContents, modifications
 }
-func (p *tablePlugin) collectRows(ctx converter.Context, headerRowNode *html.Node, rowNodes []*html.Node) [][][]byte {
-	rowContents := make([][][]byte, 0, len(rowNodes)+1)
+
+// collectRows returns the content of the rows (starting with the header row)
+// and the indexes of the section rows.
+func (p *tablePlugin) collectRows(ctx converter.Context, headerRowNodes []*html.Node, rowNodes []*html.Node) ([][][]byte, []int) {
+	rowContents := make([][][]byte, 0, len(rowNodes)+len(headerRowNodes))
 	groupedModifications := make([][]modification, 0)
 
-	// - - 1. the header row - - //
-	if headerRowNode != nil {
-		cells, mods := p.collectCellsInRow(ctx, 0, headerRowNode)
+	// - - 1. the header row(s) - - //
+	for index, headerRowNode := range headerRowNodes {
+		spanBehavior := p.spanCellBehavior
+		if len(headerRowNodes) > 1 {
+			// The group header (e.g. "Q1") belongs to every column that it spans.
+			spanBehavior = SpanBehaviorMirror
+		}
+		cells, mods := p.collectCellsInRow(ctx, index, headerRowNode, spanBehavior)
 
 		rowContents = append(rowContents, cells)
 		groupedModifications = append(groupedModifications, mods)
-	} else {
+	}
+	if len(headerRowNodes) == 0 {
 		// There needs to be *header* row so that the table is recognized.
 		// So it is better to have an empty header row...
 		rowContents = append(rowContents, [][]byte{})
 	}
+	headerCount := len(rowContents)
 
 	// - - 2. the normal rows - - //
+	var sectionRows []*html.Node
 	for index, rowNode := range rowNodes {
-		cells, mods := p.collectCellsInRow(ctx, index+1, rowNode)
+		spanBehavior := p.spanCellBehavior
+		if p.sectionRowBehavior != SectionRowBehaviorKeep && isSectionRowNode(rowNode) {
+			// The title of the section should not be repeated in every column.
+			spanBehavior = SpanBehaviorEmpty
+			sectionRows = append(sectionRows, rowNode)
+		}
+		cells, mods := p.collectCellsInRow(ctx, headerCount+index, rowNode, spanBehavior)
 
 		rowContents = append(rowContents, cells)
 		groupedModifications = append(groupedModifications, mods)
@@ -223,29 +362,125 @@ func (p *tablePlugin) collectRows(ctx converter.Context, headerRowNode *html.Nod
 	// by shifting the cells around.
 	rowContents = applyGroupedModifications(rowContents, groupedModifications)
 
+	// Every row is marked, so that we can still find the
+	// section rows after some of the rows were removed.
+	isSection := make([]bool, len(rowContents))
+	for index, rowNode := range rowNodes {
+		if slices.Contains(sectionRows, rowNode) {
+			isSection[headerCount+index] = true
+		}
+	}
+
+	if headerCount > 1 {
+		rowContents = append([][][]byte{mergeHeaderRows(rowContents[:headerCount])}, rowContents[headerCount:]...)
+		isSection = isSection[headerCount-1:]
+	}
+
 	if p.skipEmptyRows {
+		var kept []bool
+		for index, cells := range rowContents {
+			if index == 0 || !isEmptyRow(cells) {
+				kept = append(kept, isSection[index])
+			}
+		}
+		isSection = kept
 		rowContents = removeEmptyRows(rowContents)
 	}
-	if p.promoteFirstRowToHeader {
+	if p.promoteFirstRowToHeader && len(rowContents) > 0 && isEmptyRow(rowContents[0]) {
+		isSection = isSection[1:]
 		rowContents = removeFirstRowIfEmpty(rowContents)
 	}
 
-	return rowContents
+	var sectionIndexes []int
+	for index := range rowContents {
+		// A section row that became the header row is not a section anymore.
+		if index > 0 && isSection[index] {
+			sectionIndexes = append(sectionIndexes, index)
+		}
+	}
+
+	return rowContents, sectionIndexes
+}
+
+// mergeHeaderRows merges the header rows into one row, e.g. "Q1" and "Revenue" into "Q1 / Revenue".
+func mergeHeaderRows(headerRows [][][]byte) [][]byte {
+	counts := calculateMaxCounts(headerRows)
+
+	merged := make([][]byte, len(counts))
+	for x := range counts {
+		var parts [][]byte
+		for _, cells := range headerRows {
+			if x >= len(cells) || len(cells[x]) == 0 {
+				continue
+			}
+			if len(parts) > 0 && bytes.Equal(parts[len(parts)-1], cells[x]) {
+				// A cell with rowspan (e.g. "Name") should only be mentioned once.
+				continue
+			}
+			parts = append(parts, cells[x])
+		}
+		merged[x] = bytes.Join(parts, []byte(" / "))
+	}
+	return merged
 }
 
-func collectCaption(ctx converter.Context, node *html.Node) []byte {
+func (p *tablePlugin) collectCaption(ctx converter.Context, node *html.Node) []byte {
 	captionNode := dom.FindFirstNode(node, func(node *html.Node) bool {
 		return node.DataAtom == atom.Caption
 	})
-	if captionNode == nil {
+	if captionNode != nil {
+		var buf bytes.Buffer
+		ctx.RenderNodes(ctx, &buf, captionNode)
+
+		content := bytes.TrimSpace(buf.Bytes())
+		if len(content) != 0 {
+			return content
+		}
+	}
+
+	if !p.captionFallback {
 		return nil
 	}
 
-	var buf bytes.Buffer
-	ctx.RenderNodes(ctx, &buf, captionNode)
+	// Without a <caption> we fall back to the accessible name of the table.
+	text := collectLabelledByText(node)
+	if text == "" {
+		text = dom.GetAttributeOr(node, "aria-label", "")
+	}
+	if text == "" {
+		// The "summary" attribute is obsolete but still found on older websites.
+		text = dom.GetAttributeOr(node, "summary", "")
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return nil
+	}
+	return ctx.EscapeContent([]byte(text))
+}
 
-	content := buf.Bytes()
-	content = bytes.TrimSpace(content)
+// collectLabelledByText returns the text of the elements
+// that are referenced through the "aria-labelledby" attribute.
+func collectLabelledByText(node *html.Node) string {
+	ids := strings.Fields(dom.GetAttributeOr(node, "aria-labelledby", ""))
+	if len(ids) == 0 {
+		return ""
+	}
+
+	root := node
+	for root.Parent != nil {
+		root = root.Parent
+	}
 
-	return content
+	var texts []string
+	for _, id := range ids {
+		labelNode := dom.FindFirstNode(root, func(n *html.Node) bool {
+			return dom.HasID(n, id)
+		})
+		if labelNode == nil {
+			continue
+		}
+		texts = append(texts, dom.CollectText(labelNode))
+	}
+	return strings.Join(texts, " ")
 }
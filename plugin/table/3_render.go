@@ -2,25 +2,69 @@ package table
 
 import (
 	"strings"
-	"unicode/utf8"
 
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"golang.org/x/net/html"
 )
 
 func (p *tablePlugin) renderTable(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	if p.layoutTableThreshold > 0 {
+		// Converting a table that is just used for layout
+		// would result in absurd (e.g. one-cell) tables.
+		score, reasons := scoreLayoutTable(n)
+		if score >= p.layoutTableThreshold {
+			return p.renderLayoutTable(ctx, w, n, score, reasons)
+		}
+	}
+
 	table := p.collectTableContent(ctx, n)
 	if table == nil {
 		// Sometime we just cannot render the table.
 		// Either because it is an empty table OR
 		// because there are newlines inside the content (which would break the table).
+		if p.canRenderFallbackTable(n) {
+			return p.renderFallbackTable(ctx, w, n)
+		}
 		return converter.RenderTryNext
 	}
 
+	p.handleTableContent(ctx, table)
+
+	if len(table.Sections) > 0 {
+		return p.renderSectionTables(ctx, w, n, table)
+	}
+	return p.renderTableContent(ctx, w, n, table)
+}
+
+func (p *tablePlugin) renderTableContent(ctx converter.Context, w converter.Writer, n *html.Node, table *tableContent) converter.RenderStatus {
+	switch p.renderMode {
+	case RenderModeList:
+		return p.renderListTable(w, table)
+	case RenderModeHeadings:
+		return p.renderHeadingsTable(w, n, table)
+	case RenderModeTransposed:
+		if rows, ok := transposeRows(table.Rows); ok {
+			// The alignment was meant for the columns which are now rows.
+			table.Rows = rows
+			table.Alignments = nil
+		}
+	}
+
+	if table.Format == TableFormatGrid {
+		return p.renderGridTable(ctx, w, table)
+	}
+
 	// Sometimes we pad the cells with extra spaces (e.g. "| text    |").
 	// For that we first need to know the maximum width of every column.
 	counts := calculateMaxCounts(table.Rows)
 
+	// Some cells are so long that the padding would explode
+	// every other row. So the columns can be limited in width.
+	counts, ok := p.applyWidthLimits(ctx, table, counts)
+	if !ok {
+		return converter.RenderTryNext
+	}
+
 	// Sometimes a row contains less cells that another row.
 	// We then fill it up with empty cells (e.g. "| text |     |").
 	table.Rows = fillUpRows(table.Rows, len(counts))
@@ -28,6 +72,7 @@ func (p *tablePlugin) renderTable(ctx converter.Context, w converter.Writer, n *
 	// - - - - - - - - - - - - - - - - - - - - - - - - - - //
 
 	w.WriteString("\n\n")
+	p.writeCaptionAbove(w, table.Caption)
 	// - - - Header - - - //
 	p.writeRow(w, counts, table.Rows[0])
 	w.WriteString("\n")
@@ -41,11 +86,7 @@ func (p *tablePlugin) renderTable(ctx converter.Context, w converter.Writer, n *
 	}
 
 	// - - - Caption - - - //
-	if table.Caption != nil {
-		w.WriteString("\n\n")
-		w.Write(table.Caption)
-
-	}
+	p.writeCaptionBelow(w, table.Caption)
 	// - - - - - - //
 	w.WriteString("\n\n")
 
@@ -95,7 +136,7 @@ func (s *tablePlugin) writeRow(w converter.Writer, counts []int, cells [][]byte)
 			w.WriteString("|")
 		}
 
-		currentCount := utf8.RuneCount(cell)
+		currentCount := cellWidth(cell)
 		filler := counts[i] - currentCount
 
 		if s.cellPaddingBehavior == CellPaddingBehaviorAligned || s.cellPaddingBehavior == CellPaddingBehaviorMinimal {
@@ -0,0 +1,52 @@
+package table
+
+import (
+	"bytes"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/textutils"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/marker"
+)
+
+// formatCaption applies the caption style, e.g. "**Table:** The caption".
+func (p *tablePlugin) formatCaption(caption []byte) []byte {
+	switch p.captionStyle {
+	case CaptionStyleItalic:
+		return textutils.DelimiterForEveryLine(caption, []byte("*"))
+
+	case CaptionStylePrefix:
+		return append([]byte("**Table:** "), caption...)
+
+	case CaptionStylePandoc:
+		return append([]byte("Table: "), caption...)
+
+	case CaptionStyleComment:
+		// The content of a comment is not markdown, so nothing needs to be escaped.
+		caption = bytes.ReplaceAll(caption, marker.BytesMarkerEscaping, nil)
+		// A "--" inside the text would end the comment early.
+		caption = bytes.ReplaceAll(caption, []byte("--"), []byte("- -"))
+		return append(append([]byte("<!-- "), caption...), " -->"...)
+
+	default:
+		return caption
+	}
+}
+
+// writeCaptionAbove writes the caption (if any) before the table.
+// It is expected to be called right after the leading "\n\n".
+func (p *tablePlugin) writeCaptionAbove(w converter.Writer, caption []byte) {
+	if caption == nil || p.captionPosition != CaptionPositionAbove {
+		return
+	}
+	w.Write(p.formatCaption(caption))
+	w.WriteString("\n\n")
+}
+
+// writeCaptionBelow writes the caption (if any) after the table.
+func (p *tablePlugin) writeCaptionBelow(w converter.Writer, caption []byte) {
+	if caption == nil || p.captionPosition == CaptionPositionAbove {
+		return
+	}
+	w.WriteString("\n\n")
+	w.Write(p.formatCaption(caption))
+}
@@ -0,0 +1,189 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/textutils"
+	"golang.org/x/net/html"
+)
+
+// fallbackAttributes are the attributes that are kept in the html table.
+// The attributes of all the other elements are removed.
+var fallbackAttributes = map[string][]string{
+	"th":       {"colspan", "rowspan", "align", "scope"},
+	"td":       {"colspan", "rowspan", "align"},
+	"col":      {"span"},
+	"colgroup": {"span"},
+
+	"a":   {"href", "title"},
+	"img": {"src", "alt", "title", "width", "height"},
+}
+
+// fallbackUnwrapped are elements inside of the cells that
+// are only used for styling, so only their content is kept.
+var fallbackUnwrapped = map[string]struct{}{
+	"span": {},
+	"font": {},
+}
+
+func (p *tablePlugin) canRenderFallbackTable(n *html.Node) bool {
+	if p.fallbackBehavior != FallbackBehaviorHTML && p.fallbackBehavior != FallbackBehaviorHTMLWithMarkdown {
+		return false
+	}
+
+	if role := dom.GetAttributeOr(n, "role", ""); role == "presentation" && !p.convertPresentationTables {
+		// The layout tables are skipped in any case
+		return false
+	}
+	if hasProblematicParentNode(n) {
+		// An html block would break e.g. the link around the table
+		return false
+	}
+
+	cell := dom.FindFirstNode(n, func(n *html.Node) bool {
+		name := dom.NodeName(n)
+		return name == "th" || name == "td"
+	})
+	return cell != nil
+}
+
+func (p *tablePlugin) renderFallbackTable(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	var buf bytes.Buffer
+	p.writeFallbackNode(ctx, &buf, n)
+
+	w.WriteString("\n\n")
+	w.Write(bytes.TrimSpace(buf.Bytes()))
+	w.WriteString("\n\n")
+
+	return converter.RenderSuccess
+}
+
+func writeFallbackStartTag(w *bytes.Buffer, n *html.Node) {
+	name := dom.NodeName(n)
+
+	w.WriteString("<" + name)
+	for _, key := range fallbackAttributes[name] {
+		val, ok := dom.GetAttribute(n, key)
+		if !ok {
+			continue
+		}
+		w.WriteString(" " + key + `="` + html.EscapeString(val) + `"`)
+	}
+	w.WriteString(">")
+}
+
+// writeFallbackNode writes the structure of the table (e.g. <tr> and <td>)
+// and everything else that is not part of a cell is dropped.
+func (p *tablePlugin) writeFallbackNode(ctx converter.Context, w *bytes.Buffer, n *html.Node) {
+	name := dom.NodeName(n)
+	switch name {
+	case "table", "thead", "tbody", "tfoot", "tr", "colgroup":
+		writeFallbackStartTag(w, n)
+		w.WriteString("\n")
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			p.writeFallbackNode(ctx, w, child)
+		}
+		w.WriteString("</" + name + ">\n")
+
+	case "col":
+		writeFallbackStartTag(w, n)
+		w.WriteString("\n")
+
+	case "caption", "th", "td":
+		writeFallbackStartTag(w, n)
+		if p.fallbackBehavior == FallbackBehaviorHTMLWithMarkdown {
+			p.writeFallbackMarkdownContent(ctx, w, n)
+		} else {
+			p.writeFallbackHTMLContent(ctx, w, n)
+		}
+		w.WriteString("</" + name + ">\n")
+	}
+}
+
+// writeFallbackMarkdownContent renders the content as markdown. Inside of an html block
+// the content is not interpreted as markdown, except if it is surrounded by blank lines.
+func (p *tablePlugin) writeFallbackMarkdownContent(ctx converter.Context, w *bytes.Buffer, n *html.Node) {
+	var buf bytes.Buffer
+	ctx.RenderChildNodes(ctx, &buf, n)
+
+	content := bytes.TrimSpace(buf.Bytes())
+	if len(content) == 0 {
+		return
+	}
+
+	w.WriteString("\n\n")
+	w.Write(content)
+	w.WriteString("\n\n")
+}
+
+func (p *tablePlugin) writeFallbackHTMLContent(ctx converter.Context, w *bytes.Buffer, n *html.Node) {
+	container := &html.Node{
+		Type: html.ElementNode,
+		Data: "div",
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		for _, clone := range cleanFallbackNode(ctx, child) {
+			container.AppendChild(clone)
+		}
+	}
+
+	var buf bytes.Buffer
+	for child := container.FirstChild; child != nil; child = child.NextSibling {
+		_ = converter.RenderHTML(ctx, &buf, child)
+	}
+
+	content := strings.TrimSpace(buf.String())
+	w.WriteString(textutils.EncodeBlankLines(content))
+}
+
+// cleanFallbackNode returns a copy of the node without the attributes
+// that are not needed (e.g. class or style).
+func cleanFallbackNode(ctx converter.Context, n *html.Node) []*html.Node {
+	switch n.Type {
+	case html.TextNode:
+		return []*html.Node{{
+			Type: html.TextNode,
+			Data: n.Data,
+		}}
+
+	case html.ElementNode:
+		name := dom.NodeName(n)
+
+		var children []*html.Node
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			children = append(children, cleanFallbackNode(ctx, child)...)
+		}
+		if _, unwrap := fallbackUnwrapped[name]; unwrap {
+			return children
+		}
+
+		clone := &html.Node{
+			Type:     html.ElementNode,
+			Data:     n.Data,
+			DataAtom: n.DataAtom,
+		}
+		for _, key := range fallbackAttributes[name] {
+			val, ok := dom.GetAttribute(n, key)
+			if !ok {
+				continue
+			}
+			if key == "href" || key == "src" {
+				val, ok = converter.ResolveURL(ctx, name, key, val)
+				if !ok {
+					continue
+				}
+			}
+			clone.Attr = append(clone.Attr, html.Attribute{Key: key, Val: val})
+		}
+		for _, child := range children {
+			clone.AppendChild(child)
+		}
+		return []*html.Node{clone}
+	}
+
+	// Comments, doctypes, ... are not kept.
+	return nil
+}
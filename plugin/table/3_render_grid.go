@@ -0,0 +1,117 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+)
+
+// renderGridTable renders the table as a Pandoc grid table:
+//
+//	+--------+-----------+
+//	| Fruit  | Notes     |
+//	+========+===========+
+//	| Banana | - cheap   |
+//	|        | - healthy |
+//	+--------+-----------+
+//
+// Unlike pipe tables, the cells can contain block content spanning multiple lines.
+func (p *tablePlugin) renderGridTable(ctx converter.Context, w converter.Writer, table *tableContent) converter.RenderStatus {
+	// The borders need to line up, so we always pad the cells.
+	counts := calculateMaxCounts(table.Rows)
+	counts, ok := p.applyWidthLimits(ctx, table, counts)
+	if !ok {
+		return converter.RenderTryNext
+	}
+	table.Rows = fillUpRows(table.Rows, len(counts))
+
+	rows := table.Rows
+
+	// In contrast to pipe tables, grid tables don't need a header row.
+	// So the placeholder for a missing header row can be removed.
+	hasHeader := !isEmptyRow(rows[0]) || len(rows) == 1
+	if !hasHeader {
+		rows = rows[1:]
+	}
+
+	w.WriteString("\n\n")
+	p.writeCaptionAbove(w, table.Caption)
+	// - - - Header - - - //
+	if hasHeader {
+		writeGridBorder(w, counts, nil, '-')
+		writeGridRow(w, counts, rows[0])
+		writeGridBorder(w, counts, table.Alignments, '=')
+
+		rows = rows[1:]
+	} else {
+		// Without a header, the alignment is specified in the first line.
+		writeGridBorder(w, counts, table.Alignments, '-')
+	}
+
+	// - - - Body - - - //
+	for _, cells := range rows {
+		writeGridRow(w, counts, cells)
+		writeGridBorder(w, counts, nil, '-')
+	}
+
+	// - - - Caption - - - //
+	p.writeCaptionBelow(w, table.Caption)
+	// - - - - - - //
+	w.WriteString("\n\n")
+
+	return converter.RenderSuccess
+}
+
+// writeGridBorder writes a line like "+-----+:===:+" with the colons indicating the alignment.
+func writeGridBorder(w converter.Writer, counts []int, alignments []string, char byte) {
+	w.WriteString("+")
+	for i, maxLength := range counts {
+		align := getAlignmentFor(alignments, i)
+
+		if align == "left" || align == "center" {
+			w.WriteString(":")
+		} else {
+			w.WriteByte(char)
+		}
+
+		w.WriteString(strings.Repeat(string(char), maxLength))
+
+		if align == "right" || align == "center" {
+			w.WriteString(":")
+		} else {
+			w.WriteByte(char)
+		}
+		w.WriteString("+")
+	}
+	w.WriteString("\n")
+}
+
+// writeGridRow writes the cells line by line, so that block content
+// (e.g. a list) stays within the borders of the column.
+func writeGridRow(w converter.Writer, counts []int, cells [][]byte) {
+	lines := make([][][]byte, len(cells))
+	height := 1
+	for i, cell := range cells {
+		lines[i] = bytes.Split(cell, []byte("\n"))
+		height = max(height, len(lines[i]))
+	}
+
+	for y := range height {
+		w.WriteString("|")
+		for i := range cells {
+			var line []byte
+			if y < len(lines[i]) {
+				line = lines[i][y]
+			}
+
+			w.WriteString(" ")
+			w.Write(line)
+			if filler := counts[i] - stringWidth(line); filler > 0 {
+				w.WriteString(strings.Repeat(" ", filler))
+			}
+			w.WriteString(" |")
+		}
+		w.WriteString("\n")
+	}
+}
@@ -0,0 +1,169 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+// splitHeaderRow returns the header row (or nil if the table does not have one) and the other rows.
+func splitHeaderRow(rows [][][]byte) ([][]byte, [][][]byte) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	if isEmptyRow(rows[0]) {
+		// The placeholder for a missing header row.
+		return nil, rows[1:]
+	}
+	return rows[0], rows[1:]
+}
+
+// collectPairs returns the "Header: value" pairs of the row. Empty cells are skipped.
+func collectPairs(header [][]byte, cells [][]byte, indent string) []string {
+	pairs := make([]string, 0, len(cells))
+	for i, cell := range cells {
+		if len(cell) == 0 {
+			continue
+		}
+		// The lines of block content need to stay inside the list item.
+		lines := strings.Split(string(cell), "\n")
+		for j := 1; j < len(lines); j++ {
+			if lines[j] != "" {
+				lines[j] = indent + lines[j]
+			}
+		}
+		value := strings.Join(lines, "\n")
+
+		var key []byte
+		if i < len(header) {
+			key = bytes.ReplaceAll(header[i], []byte("\n"), []byte(" "))
+		}
+		if len(key) == 0 {
+			pairs = append(pairs, value)
+			continue
+		}
+		pairs = append(pairs, string(key)+": "+value)
+	}
+	return pairs
+}
+
+// renderListTable renders every row as a list item:
+//
+//   - Name: Max, City: Berlin
+//   - Name: Erika, City: Hamburg
+func (p *tablePlugin) renderListTable(w converter.Writer, table *tableContent) converter.RenderStatus {
+	header, rows := splitHeaderRow(table.Rows)
+
+	w.WriteString("\n\n")
+	p.writeCaptionAbove(w, table.Caption)
+	for _, cells := range rows {
+		pairs := collectPairs(header, cells, "  ")
+		if len(pairs) == 0 {
+			continue
+		}
+
+		w.WriteString("- ")
+		w.WriteString(strings.Join(pairs, ", "))
+		w.WriteString("\n")
+	}
+
+	p.writeCaptionBelow(w, table.Caption)
+	w.WriteString("\n\n")
+
+	return converter.RenderSuccess
+}
+
+// renderHeadingsTable renders every row as a section:
+//
+//	### Max
+//
+//	- City: Berlin
+//	- Country: Germany
+func (p *tablePlugin) renderHeadingsTable(w converter.Writer, n *html.Node, table *tableContent) converter.RenderStatus {
+	header, rows := splitHeaderRow(table.Rows)
+
+	// The sections should be below the heading that the table belongs to.
+	level := min(previousHeadingLevel(n)+1, 6)
+
+	w.WriteString("\n\n")
+	p.writeCaptionAbove(w, table.Caption)
+	for _, cells := range rows {
+		if len(cells) == 0 || isEmptyRow(cells) {
+			continue
+		}
+
+		title := bytes.Join(bytes.Fields(cells[0]), []byte(" "))
+		if len(title) != 0 {
+			w.WriteString(strings.Repeat("#", level))
+			w.WriteString(" ")
+			w.Write(title)
+			w.WriteString("\n\n")
+		}
+
+		var otherHeader [][]byte
+		if len(header) > 1 {
+			otherHeader = header[1:]
+		}
+		for _, pair := range collectPairs(otherHeader, cells[1:], "  ") {
+			w.WriteString("- ")
+			w.WriteString(pair)
+			w.WriteString("\n")
+		}
+		w.WriteString("\n")
+	}
+
+	p.writeCaptionBelow(w, table.Caption)
+	w.WriteString("\n\n")
+
+	return converter.RenderSuccess
+}
+
+// previousHeadingLevel returns the level of the heading before the node
+// or 1 if there is none (since the page title is normally the <h1>).
+func previousHeadingLevel(n *html.Node) int {
+	for node := n; node != nil; node = node.Parent {
+		for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+			headings := dom.FindAllNodes(sibling, func(n *html.Node) bool {
+				return dom.NameIsHeading(dom.NodeName(n))
+			})
+			if dom.NameIsHeading(dom.NodeName(sibling)) {
+				headings = append([]*html.Node{sibling}, headings...)
+			}
+
+			if len(headings) > 0 {
+				last := headings[len(headings)-1]
+				return int(dom.NodeName(last)[1] - '0')
+			}
+		}
+	}
+
+	return 1
+}
+
+// transposeRows swaps the rows and columns of a table with two columns. That way
+// the keys of a key-value table (e.g. an infobox) become the header row.
+func transposeRows(rows [][][]byte) ([][][]byte, bool) {
+	header, body := splitHeaderRow(rows)
+
+	all := body
+	if header != nil {
+		all = rows
+	}
+
+	counts := calculateMaxCounts(all)
+	if len(counts) != 2 {
+		return rows, false
+	}
+	all = fillUpRows(all, len(counts))
+
+	transposed := make([][][]byte, len(counts))
+	for _, cells := range all {
+		for x, cell := range cells {
+			transposed[x] = append(transposed[x], cell)
+		}
+	}
+	return transposed, true
+}
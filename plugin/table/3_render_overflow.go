@@ -0,0 +1,165 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/rivo/uniseg"
+)
+
+const ellipsis = "…"
+
+// applyWidthLimits checks the columns against the max column width and the max table width.
+// Depending on the overflow behavior the cells are truncated or the padding is removed,
+// which is reported as a warning. It returns false if the table should not be rendered.
+func (p *tablePlugin) applyWidthLimits(ctx converter.Context, table *tableContent, counts []int) ([]int, bool) {
+	limits := calculateWidthLimits(counts, p.maxColumnWidth, p.maxTableWidth)
+
+	var columns []int
+	for i, limit := range limits {
+		if limit != -1 {
+			columns = append(columns, i)
+		}
+	}
+	if len(columns) == 0 {
+		return counts, true
+	}
+
+	behavior := p.overflowBehavior
+	if behavior == OverflowBehaviorUnpadded && table.Format == TableFormatGrid {
+		// The borders of grid tables need to line up,
+		// so we cannot skip the padding.
+		behavior = OverflowBehaviorTruncate
+	}
+
+	switch behavior {
+	case OverflowBehaviorSkip:
+		converter.AddWarning(ctx, fmt.Errorf("skipped the table because the %s the max width", formatColumns(columns)))
+		return nil, false
+
+	case OverflowBehaviorUnpadded:
+		for _, i := range columns {
+			// The other rows are padded as usual but the cells
+			// of this column are only as wide as their content.
+			counts[i] = defaultCellWidth
+		}
+		converter.AddWarning(ctx, fmt.Errorf("removed the padding of the table because the %s the max width", formatColumns(columns)))
+		return counts, true
+
+	default:
+		for _, cells := range table.Rows {
+			for _, i := range columns {
+				if i < len(cells) {
+					cells[i] = truncateCell(cells[i], limits[i])
+				}
+			}
+		}
+		converter.AddWarning(ctx, fmt.Errorf("truncated the table because the %s the max width", formatColumns(columns)))
+		return calculateMaxCounts(table.Rows), true
+	}
+}
+
+// formatColumns returns e.g. "column 2 exceeds" or "columns 2, 3 exceed".
+func formatColumns(columns []int) string {
+	numbers := make([]string, 0, len(columns))
+	for _, i := range columns {
+		numbers = append(numbers, strconv.Itoa(i+1))
+	}
+
+	if len(numbers) == 1 {
+		return "column " + numbers[0] + " exceeds"
+	}
+	return "columns " + strings.Join(numbers, ", ") + " exceed"
+}
+
+// calculateWidthLimits returns the maximum width for every column
+// or -1 if the column does not need to be limited.
+func calculateWidthLimits(counts []int, maxColumnWidth, maxTableWidth int) []int {
+	limits := make([]int, len(counts))
+	widths := slices.Clone(counts)
+
+	for i, width := range widths {
+		limits[i] = -1
+		if maxColumnWidth > 0 && width > maxColumnWidth {
+			limits[i] = maxColumnWidth
+			widths[i] = maxColumnWidth
+		}
+	}
+
+	if maxTableWidth > 0 && len(widths) > 0 {
+		// Every column has a space on both sides and a "|" at the end.
+		// Additionally there is the "|" at the beginning of the row.
+		available := maxTableWidth - 1 - len(widths)*3
+
+		limit := calculateFairLimit(widths, available)
+		for i, width := range widths {
+			if width > limit {
+				limits[i] = limit
+			}
+		}
+	}
+
+	return limits
+}
+
+// calculateFairLimit returns the largest limit so that the columns fit into the available width.
+// That way only the widest columns get shortened and the narrow columns stay intact.
+func calculateFairLimit(widths []int, available int) int {
+	limit := slices.Max(widths)
+	for limit > defaultCellWidth {
+		total := 0
+		for _, width := range widths {
+			total += min(width, limit)
+		}
+		if total <= available {
+			break
+		}
+		limit--
+	}
+
+	return limit
+}
+
+func truncateCell(cell []byte, limit int) []byte {
+	lines := bytes.Split(cell, []byte("\n"))
+	for i, line := range lines {
+		lines[i] = truncateLine(line, limit)
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// truncateLine shortens the line so that it (including the ellipsis) fits into the limit.
+func truncateLine(line []byte, limit int) []byte {
+	if stringWidth(line) <= limit {
+		return line
+	}
+
+	result := make([]byte, 0, len(line))
+	width := 0
+	state := -1
+	for len(line) > 0 {
+		cluster, rest, boundaries, newState := uniseg.Step(line, state)
+
+		w := clusterWidth(cluster, boundaries)
+		if width+w > limit-1 {
+			break
+		}
+		result = append(result, cluster...)
+		width += w
+
+		line, state = rest, newState
+	}
+
+	// A trailing backslash would otherwise escape the ellipsis.
+	backslashes := len(result) - len(bytes.TrimRight(result, `\`))
+	if backslashes%2 == 1 {
+		result = result[:len(result)-1]
+	}
+	result = bytes.TrimRight(result, " ")
+
+	return append(result, ellipsis...)
+}
@@ -0,0 +1,87 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateWidthLimits(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		counts         []int
+		maxColumnWidth int
+		maxTableWidth  int
+		expected       []int
+	}{
+		{
+			desc:     "without limits",
+			counts:   []int{2, 5, 51},
+			expected: []int{-1, -1, -1},
+		},
+		{
+			desc:           "with max column width",
+			counts:         []int{2, 25, 51},
+			maxColumnWidth: 20,
+			expected:       []int{-1, 20, 20},
+		},
+		{
+			desc:          "with max table width that fits",
+			counts:        []int{2, 5, 51},
+			maxTableWidth: 68,
+			expected:      []int{-1, -1, -1},
+		},
+		{
+			desc:          "with max table width",
+			counts:        []int{2, 5, 51},
+			maxTableWidth: 30,
+			expected:      []int{-1, -1, 13},
+		},
+		{
+			desc:          "with max table width for multiple wide columns",
+			counts:        []int{2, 40, 51},
+			maxTableWidth: 40,
+			expected:      []int{-1, 14, 14},
+		},
+		{
+			desc:           "with both limits",
+			counts:         []int{30, 40, 51},
+			maxColumnWidth: 20,
+			maxTableWidth:  50,
+			expected:       []int{13, 13, 13},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			output := calculateWidthLimits(tC.counts, tC.maxColumnWidth, tC.maxTableWidth)
+			if !reflect.DeepEqual(output, tC.expected) {
+				t.Errorf("expected %v but got %v", tC.expected, output)
+			}
+		})
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	testCases := []struct {
+		input    string
+		limit    int
+		expected string
+	}{
+		{input: "abc", limit: 3, expected: "abc"},
+		{input: "abcd", limit: 3, expected: "ab…"},
+		{input: "ab cd", limit: 4, expected: "ab…"},
+		{input: "日本語", limit: 4, expected: "日…"},
+		{input: "日本語", limit: 5, expected: "日本…"},
+		{input: "👨‍👩‍👧👨‍👩‍👧", limit: 3, expected: "👨‍👩‍👧…"},
+		{input: `a\|b`, limit: 3, expected: "a…"},
+		{input: `a\\bc`, limit: 4, expected: `a\\…`},
+		{input: "first line\nsecond", limit: 6, expected: "first…\nsecond"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			output := truncateCell([]byte(tC.input), tC.limit)
+			if string(output) != tC.expected {
+				t.Errorf("expected %q but got %q", tC.expected, string(output))
+			}
+		})
+	}
+}
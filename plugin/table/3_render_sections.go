@@ -0,0 +1,52 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+// renderSectionTables renders every section as its own table,
+// with the title of the section as a heading above it:
+//
+//	## Fruits
+//
+//	| Name   | Price |
+//	|--------|-------|
+//	| Banana | 1     |
+func (p *tablePlugin) renderSectionTables(ctx converter.Context, w converter.Writer, n *html.Node, table *tableContent) converter.RenderStatus {
+	// The sections should be below the heading that the table belongs to.
+	level := min(previousHeadingLevel(n)+1, 6)
+
+	// If one of the sections can not be rendered, the
+	// whole table needs to be handled by the next renderer.
+	var buf bytes.Buffer
+
+	buf.WriteString("\n\n")
+	p.writeCaptionAbove(&buf, table.Caption)
+	for _, section := range table.Sections {
+		if len(section.Title) != 0 {
+			buf.WriteString("\n\n")
+			buf.WriteString(strings.Repeat("#", level))
+			buf.WriteString(" ")
+			buf.Write(section.Title)
+			buf.WriteString("\n\n")
+		}
+
+		status := p.renderTableContent(ctx, &buf, n, &tableContent{
+			Format:     table.Format,
+			Alignments: table.Alignments,
+			Rows:       section.Rows,
+		})
+		if status != converter.RenderSuccess {
+			return status
+		}
+	}
+	p.writeCaptionBelow(&buf, table.Caption)
+	buf.WriteString("\n\n")
+
+	w.Write(buf.Bytes())
+	return converter.RenderSuccess
+}
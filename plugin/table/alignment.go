@@ -0,0 +1,181 @@
+package table
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/JohannesKaufmann/dom"
+	"golang.org/x/net/html"
+)
+
+type alignmentClass struct {
+	align   string
+	pattern *regexp.Regexp
+}
+
+// The utility classes of common css frameworks, e.g. "text-right" (Tailwind),
+// "text-end" (Bootstrap) or "has-text-right" (Bulma).
+var defaultAlignmentClasses = []alignmentClass{
+	{align: "left", pattern: regexp.MustCompile(`^(text|has-text|align)-(left|start)$`)},
+	{align: "center", pattern: regexp.MustCompile(`^(text|has-text|align)-(center|centered)$`)},
+	{align: "right", pattern: regexp.MustCompile(`^(text|has-text|align)-(right|end)$`)},
+}
+
+// WithAlignmentClassPatterns configures the regular expressions that are matched against
+// the class names of the cells (and <col> elements) to detect the alignment of a column.
+// An empty pattern disables the detection for that alignment.
+//
+// By default the utility classes of common css frameworks are detected, e.g. "text-right".
+func WithAlignmentClassPatterns(left, center, right string) option {
+	return func(p *tablePlugin) error {
+		var classes []alignmentClass
+		for _, c := range []struct{ align, pattern string }{
+			{"left", left},
+			{"center", center},
+			{"right", right},
+		} {
+			if c.pattern == "" {
+				continue
+			}
+			pattern, err := regexp.Compile(c.pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q for %s alignment: %w", c.pattern, c.align, err)
+			}
+			classes = append(classes, alignmentClass{align: c.align, pattern: pattern})
+		}
+
+		p.alignmentClasses = classes
+		return nil
+	}
+}
+
+// WithNumericAlignment configures whether columns that mostly contain numbers
+// (e.g. "1,200", "-3.5", "$10" or "50 %") should be right-aligned.
+// This only applies to columns that have no alignment in the html.
+func WithNumericAlignment(enabled bool) option {
+	return func(p *tablePlugin) error {
+		p.numericAlignment = enabled
+		return nil
+	}
+}
+
+// getAlignment returns the alignment of a cell or <col> element. The inline
+// style takes precedence over the class names and the "align" attribute,
+// just like in the browser.
+func (p *tablePlugin) getAlignment(node *html.Node) string {
+	if align := getStyleAlignment(node); align != "" {
+		return align
+	}
+	for _, class := range dom.GetClasses(node) {
+		for _, c := range p.alignmentClasses {
+			if c.pattern.MatchString(class) {
+				return c.align
+			}
+		}
+	}
+	return dom.GetAttributeOr(node, "align", "")
+}
+
+// getStyleAlignment returns the alignment of a "text-align" declaration in the inline style.
+func getStyleAlignment(node *html.Node) string {
+	style := dom.GetAttributeOr(node, "style", "")
+
+	var align string
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, found := strings.Cut(declaration, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(property), "text-align") {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+
+		// The last declaration wins.
+		switch strings.ToLower(value) {
+		case "left", "start":
+			align = "left"
+		case "center":
+			align = "center"
+		case "right", "end":
+			align = "right"
+		default:
+			align = ""
+		}
+	}
+	return align
+}
+
+// maxColumnSpan is the highest "span" of a <col> or <colgroup> element that browsers accept.
+const maxColumnSpan = 1000
+
+// collectColumnAlignments returns the alignments that are specified
+// for the whole column through <colgroup> and <col> elements.
+func (p *tablePlugin) collectColumnAlignments(tableNode *html.Node, columnCount int) []string {
+	var alignments []string
+	appendSpan := func(node *html.Node, align string) {
+		span := min(getNumberAttributeOr(node, "span", 1), maxColumnSpan)
+		for range min(span, columnCount-len(alignments)) {
+			alignments = append(alignments, align)
+		}
+	}
+
+	for _, colgroup := range selectOwnNodes(tableNode, "colgroup") {
+		groupAlign := p.getAlignment(colgroup)
+
+		cols := selectOwnNodes(colgroup, "col")
+		if len(cols) == 0 {
+			// Without <col> elements, the colgroup itself spans over the columns.
+			appendSpan(colgroup, groupAlign)
+			continue
+		}
+
+		for _, col := range cols {
+			align := p.getAlignment(col)
+			if align == "" {
+				align = groupAlign
+			}
+			appendSpan(col, align)
+		}
+	}
+	return alignments
+}
+
+var numericR = regexp.MustCompile(`^[-+−(]?[$€£¥]?\s?\d+([.,'\s]\d+)*\s?[%$€£¥]?\)?$`)
+
+func isNumeric(cell []byte) bool {
+	// The cells are already markdown, so e.g. "1." could be escaped.
+	text := strings.ReplaceAll(string(cell), `\`, "")
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+
+	return numericR.MatchString(strings.TrimSpace(text))
+}
+
+// inferNumericAlignments right-aligns the columns without an alignment
+// where at least three quarters of the (non-empty) cells are numbers.
+func inferNumericAlignments(alignments []string, rows [][][]byte) []string {
+	_, body := splitHeaderRow(rows)
+	counts := calculateMaxCounts(rows)
+
+	for x := range counts {
+		if getAlignmentFor(alignments, x) != "" {
+			continue
+		}
+
+		var filled, numeric int
+		for _, cells := range body {
+			if x >= len(cells) || len(cells[x]) == 0 {
+				continue
+			}
+			filled++
+			if isNumeric(cells[x]) {
+				numeric++
+			}
+		}
+		if filled == 0 || numeric*4 < filled*3 {
+			continue
+		}
+
+		alignments = growSlice(alignments, x, "")
+		alignments[x] = "right"
+	}
+	return alignments
+}
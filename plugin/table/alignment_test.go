@@ -0,0 +1,30 @@
+package table
+
+import "testing"
+
+func TestIsNumeric(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{"1", true},
+		{"-3.5", true},
+		{"1,200.50", true},
+		{"1 200", true},
+		{"$10", true},
+		{"10 €", true},
+		{"50 %", true},
+		{"(42)", true},
+		{`1\.`, false},
+		{"", false},
+		{"abc", false},
+		{"3 apples", false},
+		{"2024-01-01", false},
+		{"1.2.3.4a", false},
+	}
+	for _, tC := range testCases {
+		if actual := isNumeric([]byte(tC.input)); actual != tC.expected {
+			t.Errorf("expected %v for %q but got %v", tC.expected, tC.input, actual)
+		}
+	}
+}
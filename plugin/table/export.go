@@ -0,0 +1,131 @@
+package table
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+)
+
+// Table is the structured content of a table, for example to
+// export it as csv. The content of the cells is markdown.
+type Table struct {
+	// Index is the position of the table in the document, starting at 0.
+	Index int `json:"index"`
+
+	// Caption is the content of the <caption> element.
+	Caption string `json:"caption,omitempty"`
+
+	// Alignments contains "left", "center", "right" or "" for every column.
+	Alignments []string `json:"alignments"`
+
+	// Header contains the cells of the header row.
+	// It is nil if the table does not have a header row.
+	Header []string `json:"header,omitempty"`
+
+	// Rows contains the cells of the other rows. The cells affected by colspan/rowspan
+	// are already filled in (see `WithSpanCellBehavior`) and every row has the same number of cells.
+	Rows [][]string `json:"rows"`
+}
+
+// TableHandlerFunc receives the tables of a conversion. The context
+// is the one provided through `converter.WithContext`.
+type TableHandlerFunc func(ctx context.Context, table Table)
+
+// WithTableHandler configures a function that is called for every table
+// that was collected, e.g. to export the tables as csv.
+// This also includes the tables that were not rendered as a markdown table
+// because they exceeded the max width (see `WithOverflowBehavior`).
+func WithTableHandler(fn TableHandlerFunc) option {
+	return func(p *tablePlugin) error {
+		if fn == nil {
+			return errors.New("the table handler can not be nil")
+		}
+		p.tableHandler = fn
+		return nil
+	}
+}
+
+const stateKeyTableIndex = "table_index"
+
+func (p *tablePlugin) handleTableContent(ctx converter.Context, content *tableContent) {
+	if p.tableHandler == nil {
+		return
+	}
+
+	index := converter.GetState[int](ctx, stateKeyTableIndex)
+	converter.SetState(ctx, stateKeyTableIndex, index+1)
+
+	table := newTable(index, content)
+	// Unlike the cells, the caption still contains the escaping markers.
+	table.Caption = string(ctx.UnEscapeContent(content.Caption))
+
+	p.tableHandler(ctx, table)
+}
+
+func newTable(index int, content *tableContent) Table {
+	counts := calculateMaxCounts(content.Rows)
+
+	rows := make([][]string, 0, len(content.Rows))
+	for _, cells := range content.Rows {
+		row := make([]string, len(counts))
+		for i, cell := range cells {
+			row[i] = string(cell)
+		}
+		rows = append(rows, row)
+	}
+
+	var header []string
+	if len(rows) > 0 && !isEmptyRow(content.Rows[0]) {
+		header = rows[0]
+	}
+	if len(rows) > 0 {
+		// Either the header row or the placeholder for the missing header row.
+		rows = rows[1:]
+	}
+
+	alignments := make([]string, len(counts))
+	for i := range alignments {
+		alignments[i] = getAlignmentFor(content.Alignments, i)
+	}
+
+	return Table{
+		Index:      index,
+		Alignments: alignments,
+		Header:     header,
+		Rows:       rows,
+	}
+}
+
+// WriteCSV writes the header and the rows as comma separated values.
+func (t Table) WriteCSV(w io.Writer) error {
+	return t.writeSeparatedValues(w, ',')
+}
+
+// WriteTSV writes the header and the rows as tab separated values.
+func (t Table) WriteTSV(w io.Writer) error {
+	return t.writeSeparatedValues(w, '\t')
+}
+
+func (t Table) writeSeparatedValues(w io.Writer, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	records := t.Rows
+	if t.Header != nil {
+		records = append([][]string{t.Header}, t.Rows...)
+	}
+
+	return cw.WriteAll(records)
+}
+
+// WriteJSON writes the table as an indented json object.
+func (t Table) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(t)
+}
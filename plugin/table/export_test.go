@@ -0,0 +1,146 @@
+package table
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func TestWithTableHandler(t *testing.T) {
+	input := `
+<table>
+	<caption>Prices</caption>
+	<tr>
+		<th align="left">Fruit</th>
+		<th align="right">Price</th>
+	</tr>
+	<tr>
+		<td><b>Apple</b></td>
+		<td rowspan="2">1,50 €</td>
+	</tr>
+	<tr>
+		<td>Banana</td>
+	</tr>
+</table>
+
+<table>
+	<caption>a*b</caption>
+	<tr>
+		<td>A</td>
+		<td>B</td>
+		<td>C</td>
+	</tr>
+	<tr>
+		<td>D</td>
+	</tr>
+</table>
+
+<table>
+	<tr>
+		<td><ul><li>cannot be converted</li></ul></td>
+	</tr>
+</table>
+	`
+
+	var tables []Table
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewTablePlugin(
+				WithSpanCellBehavior(SpanBehaviorMirror),
+				WithTableHandler(func(ctx context.Context, table Table) {
+					tables = append(tables, table)
+				}),
+			),
+		),
+	)
+
+	_, err := conv.ConvertString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Table{
+		{
+			Index:      0,
+			Caption:    "Prices",
+			Alignments: []string{"left", "right"},
+			Header:     []string{"Fruit", "Price"},
+			Rows: [][]string{
+				{"**Apple**", "1,50 €"},
+				{"Banana", "1,50 €"},
+			},
+		},
+		{
+			Index:      1,
+			Caption:    `a\*b`,
+			Alignments: []string{"", "", ""},
+			Rows: [][]string{
+				{"A", "B", "C"},
+				{"D", "", ""},
+			},
+		},
+	}
+	if !reflect.DeepEqual(tables, expected) {
+		t.Errorf("expected %+v but got %+v", expected, tables)
+	}
+
+	// - - - - - - - - - - - - - - - - //
+
+	var buf bytes.Buffer
+	err = tables[0].WriteCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCSV := "Fruit,Price\n**Apple**,\"1,50 €\"\nBanana,\"1,50 €\"\n"
+	if buf.String() != expectedCSV {
+		t.Errorf("expected csv %q but got %q", expectedCSV, buf.String())
+	}
+
+	buf.Reset()
+	err = tables[1].WriteTSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedTSV := "A\tB\tC\nD\t\t\n"
+	if buf.String() != expectedTSV {
+		t.Errorf("expected tsv %q but got %q", expectedTSV, buf.String())
+	}
+
+	buf.Reset()
+	err = tables[1].WriteJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `{
+  "index": 1,
+  "caption": "a\\*b",
+  "alignments": [
+    "",
+    "",
+    ""
+  ],
+  "rows": [
+    [
+      "A",
+      "B",
+      "C"
+    ],
+    [
+      "D",
+      "",
+      ""
+    ]
+  ]
+}
+`
+	if buf.String() != expectedJSON {
+		t.Errorf("expected json %q but got %q", expectedJSON, buf.String())
+	}
+}
@@ -0,0 +1,164 @@
+package table
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"golang.org/x/net/html"
+)
+
+// A cell with more text than this is considered "huge".
+const hugeCellTextLength = 300
+
+// layoutSignal is one hint that a table is used for layout purposes
+// (e.g. in html e-mails) rather than for displaying tabular data.
+type layoutSignal struct {
+	name   string
+	weight int
+	match  func(n *html.Node, cells []*html.Node) bool
+}
+
+var layoutSignals = []layoutSignal{
+	{
+		name:   "single cell",
+		weight: 3,
+		match: func(_ *html.Node, cells []*html.Node) bool {
+			return len(cells) == 1
+		},
+	},
+	{
+		name:   "nested table",
+		weight: 2,
+		match: func(n *html.Node, _ []*html.Node) bool {
+			return hasNestedTableNode(n)
+		},
+	},
+	{
+		name:   "no header cells",
+		weight: 1,
+		match: func(n *html.Node, cells []*html.Node) bool {
+			for _, cell := range cells {
+				if dom.NodeName(cell) == "th" {
+					return false
+				}
+			}
+			return len(selectOwnNodes(n, "thead")) == 0
+		},
+	},
+	{
+		name:   "huge cell with block content",
+		weight: 2,
+		match: func(_ *html.Node, cells []*html.Node) bool {
+			for _, cell := range cells {
+				if isHugeBlockCell(cell) {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		name:   "border=0 with width",
+		weight: 1,
+		match: func(n *html.Node, _ []*html.Node) bool {
+			border, hasBorder := dom.GetAttribute(n, "border")
+			_, hasWidth := dom.GetAttribute(n, "width")
+			return hasBorder && strings.TrimSpace(border) == "0" && hasWidth
+		},
+	},
+	{
+		name:   "cellpadding/cellspacing",
+		weight: 1,
+		match: func(n *html.Node, _ []*html.Node) bool {
+			_, hasPadding := dom.GetAttribute(n, "cellpadding")
+			_, hasSpacing := dom.GetAttribute(n, "cellspacing")
+			return hasPadding || hasSpacing
+		},
+	},
+}
+
+func isHugeBlockCell(cell *html.Node) bool {
+	hasBlockNode := dom.ContainsNode(cell, func(n *html.Node) bool {
+		name := dom.NodeName(n)
+		if dom.NameIsHeading(name) {
+			return true
+		}
+		switch name {
+		case "p", "div", "ul", "ol", "blockquote", "table", "hr", "pre":
+			return true
+		}
+		return false
+	})
+	if !hasBlockNode {
+		return false
+	}
+
+	text := strings.TrimSpace(dom.CollectText(cell))
+	return utf8.RuneCountInString(text) > hugeCellTextLength
+}
+
+// selectOwnNodes returns the nodes with one of the names that
+// belong to this table and not to a nested table.
+func selectOwnNodes(tableNode *html.Node, names ...string) []*html.Node {
+	var collected []*html.Node
+
+	var finder func(node *html.Node)
+	finder = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			name := dom.NodeName(child)
+			if name == "table" {
+				continue
+			}
+			if slices.Contains(names, name) {
+				collected = append(collected, child)
+				continue
+			}
+			finder(child)
+		}
+	}
+	finder(tableNode)
+
+	return collected
+}
+
+// scoreLayoutTable sums up the weights of the signals that match the table.
+func scoreLayoutTable(n *html.Node) (int, []string) {
+	cells := selectOwnNodes(n, "td", "th")
+
+	score := 0
+	var reasons []string
+	for _, signal := range layoutSignals {
+		if signal.match(n, cells) {
+			score += signal.weight
+			reasons = append(reasons, signal.name+" (+"+strconv.Itoa(signal.weight)+")")
+		}
+	}
+	return score, reasons
+}
+
+// renderLayoutTable unwraps the table, so that the content of
+// every cell is rendered as a normal block.
+func (p *tablePlugin) renderLayoutTable(ctx converter.Context, w converter.Writer, n *html.Node, score int, reasons []string) converter.RenderStatus {
+	w.WriteString("\n\n")
+	if p.layoutTableDebug {
+		w.WriteString("<!-- layout table (score ")
+		w.WriteString(strconv.Itoa(score))
+		w.WriteString(", threshold ")
+		w.WriteString(strconv.Itoa(p.layoutTableThreshold))
+		w.WriteString("): ")
+		w.WriteString(strings.Join(reasons, ", "))
+		w.WriteString(" -->\n\n")
+	}
+
+	for _, node := range selectOwnNodes(n, "caption", "td", "th") {
+		w.WriteString("\n\n")
+		ctx.RenderChildNodes(ctx, w, node)
+		w.WriteString("\n\n")
+	}
+
+	return converter.RenderSuccess
+}
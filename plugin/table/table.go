@@ -71,6 +71,196 @@ func WithNewlineBehavior(behavior NewlineBehavior) option {
 	}
 }
 
+type FallbackBehavior string
+
+const (
+	// FallbackBehaviorSkip skips tables that cannot be converted to a markdown table,
+	// so only the content of the cells is rendered (default).
+	FallbackBehaviorSkip FallbackBehavior = "skip"
+	// FallbackBehaviorHTML renders a cleaned html table instead.
+	FallbackBehaviorHTML FallbackBehavior = "html"
+	// FallbackBehaviorHTMLWithMarkdown renders a cleaned html table where the
+	// content of the cells is converted to markdown (surrounded by blank lines).
+	FallbackBehaviorHTMLWithMarkdown FallbackBehavior = "html_with_markdown"
+)
+
+// WithFallbackBehavior configures what happens with tables that cannot be converted
+// to a markdown table, e.g. because the cells contain lists, headings, nested tables
+// or newlines (with NewlineBehaviorSkip).
+// When set to FallbackBehaviorSkip (default), only the content of the cells is rendered.
+// When set to FallbackBehaviorHTML, a cleaned html table is rendered instead.
+// When set to FallbackBehaviorHTMLWithMarkdown, the cells of the html table contain markdown.
+func WithFallbackBehavior(behavior FallbackBehavior) option {
+	return func(p *tablePlugin) error {
+		switch behavior {
+		case "":
+			// Allow empty string to default to Skip
+			return nil
+
+		case FallbackBehaviorSkip, FallbackBehaviorHTML, FallbackBehaviorHTMLWithMarkdown:
+			p.fallbackBehavior = behavior
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for fallback behavior", behavior)
+		}
+	}
+}
+
+type TableFormat string
+
+const (
+	// TableFormatPipe renders pipe tables (default). Tables with block content
+	// (e.g. lists) cannot be represented and are handled by the fallback behavior.
+	TableFormatPipe TableFormat = "pipe"
+	// TableFormatGrid renders every table as a Pandoc grid table.
+	TableFormatGrid TableFormat = "grid"
+	// TableFormatAuto renders pipe tables but switches to a Pandoc grid table
+	// if the cells contain block content.
+	TableFormatAuto TableFormat = "auto"
+)
+
+// WithTableFormat configures the markdown syntax that is used for tables.
+// When set to TableFormatPipe (default), pipe tables are rendered.
+// When set to TableFormatGrid, Pandoc grid tables are rendered. Those support block content
+// like lists, headings, code blocks or multiple paragraphs inside the cells.
+// When set to TableFormatAuto, grid tables are only used for tables where the cells contain block content.
+//
+// Grid tables are always padded, independent of the cell padding behavior.
+func WithTableFormat(format TableFormat) option {
+	return func(p *tablePlugin) error {
+		switch format {
+		case "":
+			// Allow empty string to default to "pipe"
+			return nil
+
+		case TableFormatPipe, TableFormatGrid, TableFormatAuto:
+			p.tableFormat = format
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for table format", format)
+		}
+	}
+}
+
+type RenderMode string
+
+const (
+	// RenderModeTable renders a markdown table (default).
+	RenderModeTable RenderMode = "table"
+	// RenderModeList renders every row as a list item with "Header: value" pairs.
+	RenderModeList RenderMode = "list"
+	// RenderModeTransposed swaps the rows and columns of tables with two columns,
+	// so that the keys in the first column become the header row.
+	RenderModeTransposed RenderMode = "transposed"
+	// RenderModeHeadings renders every row as a section, where the
+	// first cell is the heading and the other cells are a list of "Header: value" pairs.
+	RenderModeHeadings RenderMode = "headings"
+)
+
+// WithRenderMode configures how the content of the tables is presented. Markdown tables
+// are hard to read on narrow screens, in chat apps or in e-mails.
+// When set to RenderModeTable (default), a markdown table is rendered.
+// When set to RenderModeList, every row is rendered as a list item.
+// When set to RenderModeTransposed, tables with two columns (e.g. key-value tables) are transposed.
+// When set to RenderModeHeadings, every row is rendered as a section with a heading.
+//
+// The header row is detected like for markdown tables (see also WithHeaderPromotion).
+func WithRenderMode(mode RenderMode) option {
+	return func(p *tablePlugin) error {
+		switch mode {
+		case "":
+			// Allow empty string to default to "table"
+			return nil
+
+		case RenderModeTable, RenderModeList, RenderModeTransposed, RenderModeHeadings:
+			p.renderMode = mode
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for render mode", mode)
+		}
+	}
+}
+
+type CaptionPosition string
+
+const (
+	// CaptionPositionBelow renders the caption after the table (default).
+	CaptionPositionBelow CaptionPosition = "below"
+	// CaptionPositionAbove renders the caption before the table.
+	CaptionPositionAbove CaptionPosition = "above"
+)
+
+// WithCaptionPosition configures whether the caption is rendered
+// below (default) or above the table.
+func WithCaptionPosition(position CaptionPosition) option {
+	return func(p *tablePlugin) error {
+		switch position {
+		case "":
+			// Allow empty string to default to "below"
+			return nil
+
+		case CaptionPositionBelow, CaptionPositionAbove:
+			p.captionPosition = position
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for caption position", position)
+		}
+	}
+}
+
+type CaptionStyle string
+
+const (
+	// CaptionStylePlain renders the caption as a normal paragraph (default).
+	CaptionStylePlain CaptionStyle = "plain"
+	// CaptionStyleItalic renders the caption as an italic paragraph, e.g. "*The caption*".
+	CaptionStyleItalic CaptionStyle = "italic"
+	// CaptionStylePrefix renders the caption with a bold prefix, e.g. "**Table:** The caption".
+	CaptionStylePrefix CaptionStyle = "prefix"
+	// CaptionStylePandoc renders the caption in the syntax of Pandoc, e.g. "Table: The caption".
+	CaptionStylePandoc CaptionStyle = "pandoc"
+	// CaptionStyleComment renders the caption as an html comment, e.g. "<!-- The caption -->".
+	CaptionStyleComment CaptionStyle = "comment"
+)
+
+// WithCaptionStyle configures how the caption of the table is rendered,
+// so that it is clear that the text belongs to the table.
+//
+// The caption is taken from the <caption> element. See `WithCaptionFallback`
+// for tables without a <caption>.
+func WithCaptionStyle(style CaptionStyle) option {
+	return func(p *tablePlugin) error {
+		switch style {
+		case "":
+			// Allow empty string to default to "plain"
+			return nil
+
+		case CaptionStylePlain, CaptionStyleItalic, CaptionStylePrefix, CaptionStylePandoc, CaptionStyleComment:
+			p.captionStyle = style
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for caption style", style)
+		}
+	}
+}
+
+// WithCaptionFallback configures whether the "aria-labelledby", "aria-label"
+// or "summary" attributes are used as the caption of tables without a <caption>.
+//
+// The elements referenced by "aria-labelledby" are often already
+// part of the page (e.g. a heading), so their text could appear twice.
+func WithCaptionFallback(enabled bool) option {
+	return func(p *tablePlugin) error {
+		p.captionFallback = enabled
+		return nil
+	}
+}
+
 type CellPaddingBehavior string
 
 const (
@@ -104,6 +294,76 @@ func WithCellPaddingBehavior(behavior CellPaddingBehavior) option {
 	}
 }
 
+type OverflowBehavior string
+
+const (
+	// OverflowBehaviorTruncate shortens the content of the cells
+	// and marks it with an ellipsis "…" (default).
+	OverflowBehaviorTruncate OverflowBehavior = "truncate"
+	// OverflowBehaviorUnpadded keeps the content but does not add padding for the columns
+	// that are too wide. Grid tables always need padding, so the content is truncated instead.
+	OverflowBehaviorUnpadded OverflowBehavior = "unpadded"
+	// OverflowBehaviorSkip does not render a table, so only the content of the cells is rendered.
+	OverflowBehaviorSkip OverflowBehavior = "skip"
+)
+
+// WithMaxColumnWidth configures the maximum width of the content of every column.
+// The width is measured in columns of a monospace font. When set to 0 (default),
+// the columns can be as wide as needed.
+//
+// What happens with columns that are too wide is configured with WithOverflowBehavior.
+func WithMaxColumnWidth(width int) option {
+	return func(p *tablePlugin) error {
+		if width < 0 {
+			return fmt.Errorf("invalid value %d for max column width", width)
+		}
+
+		p.maxColumnWidth = width
+		return nil
+	}
+}
+
+// WithMaxTableWidth configures the maximum width of a row, including the "|" separators
+// and the padding. The width is measured in columns of a monospace font. When set to 0 (default),
+// the table can be as wide as needed.
+//
+// To fit the table, the widest columns are limited first.
+// What happens with columns that are too wide is configured with WithOverflowBehavior.
+func WithMaxTableWidth(width int) option {
+	return func(p *tablePlugin) error {
+		if width < 0 {
+			return fmt.Errorf("invalid value %d for max table width", width)
+		}
+
+		p.maxTableWidth = width
+		return nil
+	}
+}
+
+// WithOverflowBehavior configures what happens when a table exceeds
+// the limits of WithMaxColumnWidth or WithMaxTableWidth.
+// When set to OverflowBehaviorTruncate (default), the content of the cells is truncated.
+// When set to OverflowBehaviorUnpadded, the columns that are too wide are not padded.
+// When set to OverflowBehaviorSkip, only the content of the cells is rendered.
+//
+// In every case a warning is reported to the handler from converter.WithWarningHandler.
+func WithOverflowBehavior(behavior OverflowBehavior) option {
+	return func(p *tablePlugin) error {
+		switch behavior {
+		case "":
+			// Allow empty string to default to "truncate"
+			return nil
+
+		case OverflowBehaviorTruncate, OverflowBehaviorUnpadded, OverflowBehaviorSkip:
+			p.overflowBehavior = behavior
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for overflow behavior", behavior)
+		}
+	}
+}
+
 // WithSkipEmptyRows configures the table plugin to omit empty rows from the output.
 // An empty row is defined as a row where all cells contain no content or only whitespace.
 // When set to true, empty rows will be omitted from the output. When false (default),
@@ -126,6 +386,50 @@ func WithHeaderPromotion(promote bool) option {
 	}
 }
 
+// WithHeaderRowMerging configures whether tables with multiple header rows
+// (e.g. a group header above the subheaders) should have their header rows
+// merged into one row with compound column names (e.g. "Q1 / Revenue").
+// When false (default), only the first row is used as the header row.
+func WithHeaderRowMerging(merge bool) option {
+	return func(p *tablePlugin) error {
+		p.mergeHeaderRows = merge
+		return nil
+	}
+}
+
+type SectionRowBehavior string
+
+const (
+	// SectionRowBehaviorKeep renders section rows like every other row (default).
+	SectionRowBehaviorKeep SectionRowBehavior = "keep"
+	// SectionRowBehaviorSeparator renders section rows as a separator row
+	// with the bold title in the first cell, e.g. "| **Fruits** |   |".
+	SectionRowBehaviorSeparator SectionRowBehavior = "separator"
+	// SectionRowBehaviorSplit splits the table into several tables,
+	// each with the title of the section as a heading above it.
+	SectionRowBehaviorSplit SectionRowBehavior = "split"
+)
+
+// WithSectionRowBehavior configures how section rows are rendered. A section row
+// groups the rows below it and consists of a single <th> that spans over the
+// other columns, e.g. <tr><th colspan="3">Fruits</th></tr>
+func WithSectionRowBehavior(behavior SectionRowBehavior) option {
+	return func(p *tablePlugin) error {
+		switch behavior {
+		case "":
+			// Allow empty string to default to "keep"
+			return nil
+
+		case SectionRowBehaviorKeep, SectionRowBehaviorSeparator, SectionRowBehaviorSplit:
+			p.sectionRowBehavior = behavior
+			return nil
+
+		default:
+			return fmt.Errorf("unknown value %q for section row behavior", behavior)
+		}
+	}
+}
+
 // WithPresentationTables configures whether tables marked with role="presentation"
 // should be converted to markdown. When set to true, presentation tables will be
 // converted like regular tables. When false (default), these tables are skipped
@@ -137,16 +441,68 @@ func WithPresentationTables(convert bool) option {
 	}
 }
 
+// WithLayoutTableThreshold enables the detection of tables that are used for layout purposes
+// (e.g. in html e-mails and on legacy websites) rather than for tabular data. Instead of
+// a markdown table, the content of every cell is rendered as a normal block.
+//
+// Every signal adds to the score of the table:
+//   - a single cell (+3)
+//   - a nested table (+2)
+//   - no header cells (+1)
+//   - a huge cell with block content, e.g. paragraphs (+2)
+//   - border="0" together with a width attribute (+1)
+//   - cellpadding or cellspacing attributes (+1)
+//
+// If the score reaches the threshold, the table is treated as a layout table.
+// A threshold of 3 is a good starting point, lower values unwrap more tables.
+// When set to 0 (default), the detection is disabled.
+func WithLayoutTableThreshold(threshold int) option {
+	return func(p *tablePlugin) error {
+		if threshold < 0 {
+			return fmt.Errorf("invalid value %d for layout table threshold", threshold)
+		}
+
+		p.layoutTableThreshold = threshold
+		return nil
+	}
+}
+
+// WithLayoutTableDebug configures whether an html comment explaining the score
+// is added before the content of tables detected as layout tables (see WithLayoutTableThreshold).
+// This helps with tuning the threshold.
+func WithLayoutTableDebug(debug bool) option {
+	return func(p *tablePlugin) error {
+		p.layoutTableDebug = debug
+		return nil
+	}
+}
+
 type tablePlugin struct {
 	m   sync.RWMutex
 	err error
 
 	spanCellBehavior          SpanCellBehavior
 	newlineBehavior           NewlineBehavior
+	fallbackBehavior          FallbackBehavior
 	skipEmptyRows             bool
 	promoteFirstRowToHeader   bool
 	convertPresentationTables bool
 	cellPaddingBehavior       CellPaddingBehavior
+	tableFormat               TableFormat
+	maxColumnWidth            int
+	maxTableWidth             int
+	overflowBehavior          OverflowBehavior
+	tableHandler              TableHandlerFunc
+	renderMode                RenderMode
+	layoutTableThreshold      int
+	layoutTableDebug          bool
+	captionPosition           CaptionPosition
+	captionStyle              CaptionStyle
+	captionFallback           bool
+	mergeHeaderRows           bool
+	sectionRowBehavior        SectionRowBehavior
+	alignmentClasses          []alignmentClass
+	numericAlignment          bool
 }
 
 func (p *tablePlugin) setError(err error) {
@@ -165,6 +521,13 @@ func (p *tablePlugin) getError() error {
 func NewTablePlugin(opts ...option) converter.Plugin {
 	plugin := &tablePlugin{
 		cellPaddingBehavior: CellPaddingBehaviorAligned,
+		tableFormat:         TableFormatPipe,
+		overflowBehavior:    OverflowBehaviorTruncate,
+		renderMode:          RenderModeTable,
+		captionPosition:     CaptionPositionBelow,
+		captionStyle:        CaptionStylePlain,
+		sectionRowBehavior:  SectionRowBehaviorKeep,
+		alignmentClasses:    defaultAlignmentClasses,
 	}
 	for _, opt := range opts {
 		err := opt(plugin)
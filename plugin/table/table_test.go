@@ -2,6 +2,7 @@ package table
 
 import (
 	"bytes"
+	"slices"
 	"strings"
 	"testing"
 
@@ -719,6 +720,32 @@ func TestOptionFunc_CellPaddingBehavior(t *testing.T) {
 | B1                                                               | This one has longer text than the line above. |
 			`,
 		},
+		{
+			desc:    "with padding behavior and wide characters",
+			options: []option{},
+			input: `
+<table>
+  <tr>
+    <th>Name</th>
+    <th>Note</th>
+  </tr>
+  <tr>
+    <td>山田太郎</td>
+    <td>👨‍👩‍👧 family</td>
+  </tr>
+  <tr>
+    <td>Zoe&#x0301;</td>
+    <td>a | b</td>
+  </tr>
+</table>
+			`,
+			expected: `
+| Name     | Note      |
+|----------|-----------|
+| 山田太郎 | 👨‍👩‍👧 family |
+| Zoé      | a \| b    |
+			`,
+		},
 		{
 			desc: "with minimal padding behavior",
 			options: []option{
@@ -793,3 +820,1734 @@ func TestOptionFunc_CellPaddingBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionFunc_FallbackBehavior(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc: "with skip behavior (default)",
+			options: []option{
+				WithFallbackBehavior(FallbackBehaviorSkip),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A</td>
+		<td><ul><li>one</li><li>two</li></ul></td>
+	</tr>
+</table>
+			`,
+			expected: `
+A
+
+- one
+- two
+			`,
+		},
+		{
+			desc: "with html behavior",
+			options: []option{
+				WithFallbackBehavior(FallbackBehaviorHTML),
+			},
+			input: `
+<table class="data" style="width: 100%">
+	<thead>
+		<tr><th colspan="2" class="head">Name</th></tr>
+	</thead>
+	<tr>
+		<td><span class="a">A <a href="/page" class="l">*link*</a></span></td>
+		<td><!-- comment --><ul><li>one</li><li>two</li></ul></td>
+	</tr>
+	<tr>
+		<td><pre>a
+
+b</pre></td>
+		<td>1 &lt; 2</td>
+	</tr>
+</table>
+			`,
+			expected: `
+<table>
+<thead>
+<tr>
+<th colspan="2">Name</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td>A <a href="/page">*link*</a></td>
+<td><ul><li>one</li><li>two</li></ul></td>
+</tr>
+<tr>
+<td><pre>a
+&#10;b</pre></td>
+<td>1 &lt; 2</td>
+</tr>
+</tbody>
+</table>
+			`,
+		},
+		{
+			desc: "with html_with_markdown behavior",
+			options: []option{
+				WithFallbackBehavior(FallbackBehaviorHTMLWithMarkdown),
+			},
+			input: `
+<table>
+	<caption>The <b>caption</b></caption>
+	<tr>
+		<td>A <a href="/page">*link*</a></td>
+		<td><ul><li>one</li><li>two</li></ul></td>
+		<td></td>
+	</tr>
+</table>
+			`,
+			expected: `
+<table>
+<caption>
+
+The **caption**
+
+</caption>
+<tbody>
+<tr>
+<td>
+
+A [\*link\*](/page)
+
+</td>
+<td>
+
+- one
+- two
+
+</td>
+<td></td>
+</tr>
+</tbody>
+</table>
+			`,
+		},
+		{
+			desc: "with html behavior but a normal table",
+			options: []option{
+				WithFallbackBehavior(FallbackBehaviorHTML),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A</td>
+		<td>B</td>
+	</tr>
+</table>
+			`,
+			expected: `
+|   |   |
+|---|---|
+| A | B |
+			`,
+		},
+		{
+			desc: "with html behavior but a presentation table",
+			options: []option{
+				WithFallbackBehavior(FallbackBehaviorHTML),
+			},
+			input: `
+<table role="presentation">
+	<tr>
+		<td><h1>Newsletter</h1></td>
+	</tr>
+</table>
+			`,
+			expected: `
+# Newsletter
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_TableFormat(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc: "with pipe format (default)",
+			options: []option{
+				WithTableFormat(TableFormatPipe),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A</td>
+		<td><ul><li>one</li><li>two</li></ul></td>
+	</tr>
+</table>
+			`,
+			expected: `
+A
+
+- one
+- two
+			`,
+		},
+		{
+			desc: "with grid format",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+			},
+			input: `
+<table>
+	<tr>
+		<th align="left">Fruit</th>
+		<th align="right">Notes</th>
+	</tr>
+	<tr>
+		<td>Banana</td>
+		<td><ul><li>cheap</li><li>healthy</li></ul></td>
+	</tr>
+	<tr>
+		<td><h2>Apple</h2><p>green</p></td>
+		<td>sour</td>
+	</tr>
+</table>
+			`,
+			expected: `
++----------+-----------+
+| Fruit    | Notes     |
++:=========+==========:+
+| Banana   | - cheap   |
+|          | - healthy |
++----------+-----------+
+| ## Apple | sour      |
+|          |           |
+| green    |           |
++----------+-----------+
+			`,
+		},
+		{
+			desc: "with grid format and without header",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+			},
+			input: `
+<table>
+	<tr>
+		<td align="center">A</td>
+		<td>B</td>
+	</tr>
+</table>
+			`,
+			expected: `
++:-:+---+
+| A | B |
++---+---+
+			`,
+		},
+		{
+			desc: "with grid format and colspan/rowspan",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+				WithSpanCellBehavior(SpanBehaviorMirror),
+			},
+			input: `
+<table>
+	<tr>
+		<td rowspan="2"><p>A</p><p>B</p></td>
+		<td>C</td>
+	</tr>
+	<tr>
+		<td>D</td>
+	</tr>
+	<tr>
+		<td colspan="2">E</td>
+	</tr>
+</table>
+			`,
+			expected: `
++---+---+
+| A | C |
+|   |   |
+| B |   |
++---+---+
+| A | D |
+|   |   |
+| B |   |
++---+---+
+| E | E |
++---+---+
+			`,
+		},
+		{
+			desc: "with grid format and east asian wide characters",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+			},
+			input: `
+<table>
+	<tr>
+		<th>名前</th>
+		<th>Note</th>
+	</tr>
+	<tr>
+		<td>山田太郎</td>
+		<td><ul><li>日本語</li><li>x</li></ul></td>
+	</tr>
+</table>
+			`,
+			expected: `
++----------+----------+
+| 名前     | Note     |
++==========+==========+
+| 山田太郎 | - 日本語 |
+|          | - x      |
++----------+----------+
+			`,
+		},
+		{
+			desc: "with grid format and caption",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+			},
+			input: `
+<table>
+	<caption>The caption</caption>
+	<tr>
+		<th>A</th>
+	</tr>
+	<tr>
+		<td>B</td>
+	</tr>
+</table>
+			`,
+			expected: `
++---+
+| A |
++===+
+| B |
++---+
+
+The caption
+			`,
+		},
+		{
+			desc: "with auto format and simple content",
+			options: []option{
+				WithTableFormat(TableFormatAuto),
+			},
+			input: `
+<table>
+	<tr>
+		<th>A</th>
+		<th>B</th>
+	</tr>
+</table>
+			`,
+			expected: `
+| A | B |
+|---|---|
+			`,
+		},
+		{
+			desc: "with auto format and block content",
+			options: []option{
+				WithTableFormat(TableFormatAuto),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A</td>
+		<td><pre>line 1
+line 2</pre></td>
+	</tr>
+</table>
+			`,
+			expected: "+---+--------+\n| A | ```    |\n|   | line 1 |\n|   | line 2 |\n|   | ```    |\n+---+--------+",
+		},
+		{
+			desc: "with auto format and preserved newlines",
+			options: []option{
+				WithTableFormat(TableFormatAuto),
+				WithNewlineBehavior(NewlineBehaviorPreserve),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A<br />B</td>
+	</tr>
+</table>
+			`,
+			expected: `
+|            |
+|------------|
+| A  <br />B |
+			`,
+		},
+		{
+			// Only the inner table can be converted.
+			desc: "with grid format and nested table",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+			},
+			input: `
+<table>
+	<tr>
+		<td><table><tr><td>A</td></tr></table></td>
+	</tr>
+</table>
+			`,
+			expected: `
++---+
+| A |
++---+
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_MaxWidth(t *testing.T) {
+	input := `
+<table>
+	<tr>
+		<th>ID</th>
+		<th>Name</th>
+		<th>Payload</th>
+	</tr>
+	<tr>
+		<td>1</td>
+		<td>Alice</td>
+		<td>{"id": 1, "tags": ["a", "b", "c"], "active": true}</td>
+	</tr>
+	<tr>
+		<td>2</td>
+		<td>Bob</td>
+		<td>{}</td>
+	</tr>
+</table>
+	`
+
+	testCases := []struct {
+		desc             string
+		options          []option
+		expected         string
+		expectedWarnings []string
+	}{
+		{
+			desc:    "without limits (default)",
+			options: []option{},
+			expected: `
+| ID | Name  | Payload                                             |
+|----|-------|-----------------------------------------------------|
+| 1  | Alice | {"id": 1, "tags": \["a", "b", "c"], "active": true} |
+| 2  | Bob   | {}                                                  |
+			`,
+		},
+		{
+			desc: "with max column width",
+			options: []option{
+				WithMaxColumnWidth(20),
+			},
+			expected: `
+| ID | Name  | Payload            |
+|----|-------|--------------------|
+| 1  | Alice | {"id": 1, "tags":… |
+| 2  | Bob   | {}                 |
+			`,
+			expectedWarnings: []string{
+				"truncated the table because the column 3 exceeds the max width",
+			},
+		},
+		{
+			desc: "with max table width",
+			options: []option{
+				WithMaxTableWidth(30),
+			},
+			expected: `
+| ID | Name  | Payload       |
+|----|-------|---------------|
+| 1  | Alice | {"id": 1, "t… |
+| 2  | Bob   | {}            |
+			`,
+			expectedWarnings: []string{
+				"truncated the table because the column 3 exceeds the max width",
+			},
+		},
+		{
+			desc: "with max table width that is too small for every column",
+			options: []option{
+				WithMaxTableWidth(16),
+			},
+			expected: `
+| ID | N… | P… |
+|----|----|----|
+| 1  | A… | {… |
+| 2  | B… | {} |
+			`,
+			expectedWarnings: []string{
+				"truncated the table because the columns 2, 3 exceed the max width",
+			},
+		},
+		{
+			desc: "with unpadded overflow behavior",
+			options: []option{
+				WithMaxColumnWidth(20),
+				WithOverflowBehavior(OverflowBehaviorUnpadded),
+			},
+			expected: `
+| ID | Name  | Payload |
+|----|-------|---|
+| 1  | Alice | {"id": 1, "tags": \["a", "b", "c"], "active": true} |
+| 2  | Bob   | {} |
+			`,
+			expectedWarnings: []string{
+				"removed the padding of the table because the column 3 exceeds the max width",
+			},
+		},
+		{
+			desc: "with skip overflow behavior",
+			options: []option{
+				WithMaxColumnWidth(20),
+				WithOverflowBehavior(OverflowBehaviorSkip),
+			},
+			expected: "ID Name Payload \n\n1 Alice {\"id\": 1, \"tags\": \\[\"a\", \"b\", \"c\"], \"active\": true} \n\n2 Bob {}",
+			expectedWarnings: []string{
+				"skipped the table because the column 3 exceeds the max width",
+			},
+		},
+		{
+			desc: "with grid format and unpadded overflow behavior",
+			options: []option{
+				WithTableFormat(TableFormatGrid),
+				WithMaxColumnWidth(20),
+				WithOverflowBehavior(OverflowBehaviorUnpadded),
+			},
+			expected: `
++----+-------+--------------------+
+| ID | Name  | Payload            |
++====+=======+====================+
+| 1  | Alice | {"id": 1, "tags":… |
++----+-------+--------------------+
+| 2  | Bob   | {}                 |
++----+-------+--------------------+
+			`,
+			expectedWarnings: []string{
+				"truncated the table because the column 3 exceeds the max width",
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			var warnings []string
+			output, err := conv.ConvertString(input,
+				converter.WithWarningHandler(func(err error) {
+					warnings = append(warnings, err.Error())
+				}),
+			)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+			if !slices.Equal(warnings, tC.expectedWarnings) {
+				t.Errorf("expected warnings %q but got %q", tC.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_RenderMode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc: "with list mode",
+			options: []option{
+				WithRenderMode(RenderModeList),
+			},
+			input: `
+<table>
+	<caption>People</caption>
+	<tr>
+		<th>Name</th>
+		<th>City</th>
+		<th>Note</th>
+	</tr>
+	<tr>
+		<td>Max</td>
+		<td>Berlin</td>
+		<td></td>
+	</tr>
+	<tr>
+		<td>Erika</td>
+		<td>Hamburg</td>
+		<td><b>fan</b></td>
+	</tr>
+</table>
+			`,
+			expected: `
+- Name: Max, City: Berlin
+- Name: Erika, City: Hamburg, Note: **fan**
+
+People
+			`,
+		},
+		{
+			desc: "with list mode and without header",
+			options: []option{
+				WithRenderMode(RenderModeList),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A1</td>
+		<td>A2</td>
+	</tr>
+	<tr>
+		<td>B1</td>
+		<td>B2</td>
+	</tr>
+</table>
+			`,
+			expected: `
+- A1, A2
+- B1, B2
+			`,
+		},
+		{
+			desc: "with list mode and header promotion",
+			options: []option{
+				WithRenderMode(RenderModeList),
+				WithHeaderPromotion(true),
+			},
+			input: `
+<table>
+	<tr>
+		<td>Name</td>
+		<td>City</td>
+	</tr>
+	<tr>
+		<td>Max</td>
+		<td>Berlin</td>
+	</tr>
+</table>
+			`,
+			expected: `
+- Name: Max, City: Berlin
+			`,
+		},
+		{
+			desc: "with list mode and grid format",
+			options: []option{
+				WithRenderMode(RenderModeList),
+				WithTableFormat(TableFormatGrid),
+			},
+			input: `
+<table>
+	<tr>
+		<th>Name</th>
+		<th>Skills</th>
+	</tr>
+	<tr>
+		<td>Max</td>
+		<td><p>Go</p><p>SQL</p></td>
+	</tr>
+</table>
+			`,
+			expected: `
+- Name: Max, Skills: Go
+
+  SQL
+			`,
+		},
+		{
+			desc: "with headings mode",
+			options: []option{
+				WithRenderMode(RenderModeHeadings),
+			},
+			input: `
+<h2>People</h2>
+<div>
+	<table>
+		<tr>
+			<th>Name</th>
+			<th>City</th>
+			<th>Country</th>
+		</tr>
+		<tr>
+			<td>Max</td>
+			<td>Berlin</td>
+			<td>Germany</td>
+		</tr>
+		<tr>
+			<td></td>
+			<td>Paris</td>
+			<td>France</td>
+		</tr>
+	</table>
+</div>
+			`,
+			expected: `
+## People
+
+### Max
+
+- City: Berlin
+- Country: Germany
+
+- City: Paris
+- Country: France
+			`,
+		},
+		{
+			desc: "with headings mode and without previous heading",
+			options: []option{
+				WithRenderMode(RenderModeHeadings),
+			},
+			input: `
+<table>
+	<tr>
+		<td>Max</td>
+		<td>Berlin</td>
+	</tr>
+</table>
+			`,
+			expected: `
+## Max
+
+- Berlin
+			`,
+		},
+		{
+			desc: "with transposed mode",
+			options: []option{
+				WithRenderMode(RenderModeTransposed),
+			},
+			input: `
+<table>
+	<tr>
+		<td>Born</td>
+		<td>1990</td>
+	</tr>
+	<tr>
+		<td>Died</td>
+		<td>2050</td>
+	</tr>
+</table>
+			`,
+			expected: `
+| Born | Died |
+|------|------|
+| 1990 | 2050 |
+			`,
+		},
+		{
+			desc: "with transposed mode and header",
+			options: []option{
+				WithRenderMode(RenderModeTransposed),
+			},
+			input: `
+<table>
+	<tr>
+		<th align="right">Key</th>
+		<th>Value</th>
+	</tr>
+	<tr>
+		<td>A</td>
+		<td>1</td>
+	</tr>
+</table>
+			`,
+			expected: `
+| Key   | A |
+|-------|---|
+| Value | 1 |
+			`,
+		},
+		{
+			desc: "with transposed mode and three columns",
+			options: []option{
+				WithRenderMode(RenderModeTransposed),
+			},
+			input: `
+<table>
+	<tr>
+		<td>A</td>
+		<td>B</td>
+		<td>C</td>
+	</tr>
+</table>
+			`,
+			expected: `
+|   |   |   |
+|---|---|---|
+| A | B | C |
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_LayoutTable(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc:    "without detection (default)",
+			options: []option{},
+			input: `
+<table>
+	<tr>
+		<td>Welcome to our newsletter</td>
+	</tr>
+</table>
+			`,
+			expected: `
+|                           |
+|---------------------------|
+| Welcome to our newsletter |
+			`,
+		},
+		{
+			desc: "with single cell",
+			options: []option{
+				WithLayoutTableThreshold(3),
+			},
+			input: `
+<table>
+	<tr>
+		<td>Welcome to our newsletter</td>
+	</tr>
+</table>
+			`,
+			expected: `
+Welcome to our newsletter
+			`,
+		},
+		{
+			desc: "with nested layout tables",
+			options: []option{
+				WithLayoutTableThreshold(3),
+			},
+			input: `
+<table width="600" border="0" cellpadding="0" cellspacing="0">
+	<tr>
+		<td><h1>Newsletter</h1></td>
+	</tr>
+	<tr>
+		<td>
+			<table width="100%" border="0" cellspacing="0">
+				<tr>
+					<td><p>Sidebar</p></td>
+					<td><p>Main content</p></td>
+				</tr>
+			</table>
+		</td>
+	</tr>
+	<tr>
+		<td>
+			<table>
+				<tr><th>Product</th><th>Price</th></tr>
+				<tr><td>Apple</td><td>1.00</td></tr>
+			</table>
+		</td>
+	</tr>
+</table>
+			`,
+			expected: `
+# Newsletter
+
+Sidebar
+
+Main content
+
+| Product | Price |
+|---------|-------|
+| Apple   | 1.00  |
+			`,
+		},
+		{
+			desc: "with data table below the threshold",
+			options: []option{
+				WithLayoutTableThreshold(3),
+			},
+			input: `
+<table border="0" width="100%" cellpadding="4">
+	<tr>
+		<th>A1</th>
+		<th>A2</th>
+	</tr>
+</table>
+			`,
+			expected: `
+| A1 | A2 |
+|----|----|
+			`,
+		},
+		{
+			desc: "with huge cell",
+			options: []option{
+				WithLayoutTableThreshold(3),
+			},
+			input:    "<table><tr><td>Menu</td><td><p>" + strings.Repeat("text ", 70) + "</p></td></tr></table>",
+			expected: "Menu\n\n" + strings.Repeat("text ", 70),
+		},
+		{
+			desc: "with lower threshold",
+			options: []option{
+				WithLayoutTableThreshold(2),
+			},
+			input: `
+<table cellpadding="4">
+	<tr>
+		<td>A1</td>
+		<td>A2</td>
+	</tr>
+</table>
+			`,
+			expected: `
+A1
+
+A2
+			`,
+		},
+		{
+			desc: "with debug explanation",
+			options: []option{
+				WithLayoutTableThreshold(3),
+				WithLayoutTableDebug(true),
+			},
+			input: `
+<table border="0" width="100%">
+	<tr>
+		<td>Content</td>
+	</tr>
+</table>
+			`,
+			expected: `
+<!-- layout table (score 5, threshold 3): single cell (+3), no header cells (+1), border=0 with width (+1) -->
+
+Content
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_Caption(t *testing.T) {
+	const input = `
+<table>
+	<caption>Fruit <b>prices</b></caption>
+	<tr>
+		<th>Fruit</th>
+		<th>Price</th>
+	</tr>
+	<tr>
+		<td>Apple</td>
+		<td>1</td>
+	</tr>
+</table>
+	`
+
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc:    "default",
+			input:   input,
+			options: []option{},
+			expected: `
+| Fruit | Price |
+|-------|-------|
+| Apple | 1     |
+
+Fruit **prices**
+			`,
+		},
+		{
+			desc:  "above",
+			input: input,
+			options: []option{
+				WithCaptionPosition(CaptionPositionAbove),
+			},
+			expected: `
+Fruit **prices**
+
+| Fruit | Price |
+|-------|-------|
+| Apple | 1     |
+			`,
+		},
+		{
+			desc:  "italic",
+			input: input,
+			options: []option{
+				WithCaptionStyle(CaptionStyleItalic),
+			},
+			expected: `
+| Fruit | Price |
+|-------|-------|
+| Apple | 1     |
+
+*Fruit **prices***
+			`,
+		},
+		{
+			desc:  "prefix above",
+			input: input,
+			options: []option{
+				WithCaptionPosition(CaptionPositionAbove),
+				WithCaptionStyle(CaptionStylePrefix),
+			},
+			expected: `
+**Table:** Fruit **prices**
+
+| Fruit | Price |
+|-------|-------|
+| Apple | 1     |
+			`,
+		},
+		{
+			desc:  "pandoc",
+			input: input,
+			options: []option{
+				WithCaptionStyle(CaptionStylePandoc),
+			},
+			expected: `
+| Fruit | Price |
+|-------|-------|
+| Apple | 1     |
+
+Table: Fruit **prices**
+			`,
+		},
+		{
+			desc: "comment",
+			input: `
+<table>
+	<caption>Prices -- updated daily</caption>
+	<tr>
+		<td>Apple</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithCaptionStyle(CaptionStyleComment),
+			},
+			expected: `
+|       |
+|-------|
+| Apple |
+
+<!-- Prices - - updated daily -->
+			`,
+		},
+		{
+			desc:  "comment with list mode",
+			input: input,
+			options: []option{
+				WithRenderMode(RenderModeList),
+				WithCaptionPosition(CaptionPositionAbove),
+				WithCaptionStyle(CaptionStyleComment),
+			},
+			expected: `
+<!-- Fruit **prices** -->
+
+- Fruit: Apple, Price: 1
+			`,
+		},
+		{
+			desc: "fallback to aria-labelledby",
+			input: `
+<h2 id="title">Fruit</h2>
+<p id="subtitle">
+	Prices   per kg
+</p>
+<table aria-labelledby="title subtitle missing" aria-label="Other" summary="Other">
+	<tr>
+		<td>Apple</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithCaptionStyle(CaptionStylePandoc),
+				WithCaptionFallback(true),
+			},
+			expected: `
+## Fruit
+
+Prices per kg
+
+|       |
+|-------|
+| Apple |
+
+Table: Fruit Prices per kg
+			`,
+		},
+		{
+			desc: "fallback to aria-label",
+			input: `
+<table aria-label="Fruit *prices*" summary="Other">
+	<tr>
+		<td>Apple</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithCaptionFallback(true),
+			},
+			expected: `
+|       |
+|-------|
+| Apple |
+
+Fruit \*prices*
+			`,
+		},
+		{
+			desc: "fallback to summary for empty caption",
+			input: `
+<table summary="Fruit prices">
+	<caption> </caption>
+	<tr>
+		<td>Apple</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithCaptionFallback(true),
+			},
+			expected: `
+|       |
+|-------|
+| Apple |
+
+Fruit prices
+			`,
+		},
+		{
+			desc: "no fallback by default",
+			input: `
+<h2 id="title">Prices</h2>
+<table aria-labelledby="title" aria-label="Other" summary="Other">
+	<tr>
+		<td>Apple</td>
+	</tr>
+</table>
+			`,
+			options: []option{},
+			expected: `
+## Prices
+
+|       |
+|-------|
+| Apple |
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_HeaderRowsAndSections(t *testing.T) {
+	const multiRowHeader = `
+<table>
+	<thead>
+		<tr>
+			<th rowspan="2">Name</th>
+			<th colspan="2">Q1</th>
+			<th colspan="2">Q2</th>
+		</tr>
+		<tr>
+			<th>Revenue</th>
+			<th>Profit</th>
+			<th>Revenue</th>
+			<th>Profit</th>
+		</tr>
+	</thead>
+	<tbody>
+		<tr>
+			<td>Max</td>
+			<td>10</td>
+			<td>2</td>
+			<td>12</td>
+			<td>3</td>
+		</tr>
+	</tbody>
+</table>
+	`
+	const sectionRows = `
+<h2>Prices</h2>
+<table>
+	<tr>
+		<th>Name</th>
+		<th>Price</th>
+	</tr>
+	<tr>
+		<td>Total</td>
+		<td>3</td>
+	</tr>
+	<tbody>
+		<tr>
+			<th colspan="2">Fruits</th>
+		</tr>
+		<tr>
+			<td>Apple</td>
+			<td>1</td>
+		</tr>
+	</tbody>
+	<tbody>
+		<tr>
+			<th colspan="2">Vegetables</th>
+		</tr>
+		<tr>
+			<td>Carrot</td>
+			<td>2</td>
+		</tr>
+	</tbody>
+</table>
+	`
+
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc:  "merge multi-row header",
+			input: multiRowHeader,
+			options: []option{
+				WithHeaderRowMerging(true),
+			},
+			expected: `
+| Name | Q1 / Revenue | Q1 / Profit | Q2 / Revenue | Q2 / Profit |
+|------|--------------|-------------|--------------|-------------|
+| Max  | 10           | 2           | 12           | 3           |
+			`,
+		},
+		{
+			desc: "merge multi-row header without thead",
+			input: `
+<table>
+	<tr>
+		<th></th>
+		<th colspan="2">Size</th>
+	</tr>
+	<tr>
+		<th>Name</th>
+		<th>Width</th>
+		<th>Height</th>
+	</tr>
+	<tr>
+		<th>Door</th>
+		<td>1</td>
+		<td>2</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithHeaderRowMerging(true),
+			},
+			expected: `
+| Name | Size / Width | Size / Height |
+|------|--------------|---------------|
+| Door | 1            | 2             |
+			`,
+		},
+		{
+			desc:  "section rows by default",
+			input: sectionRows,
+			options: []option{
+				WithSpanCellBehavior(SpanBehaviorMirror),
+			},
+			expected: `
+## Prices
+
+| Name       | Price      |
+|------------|------------|
+| Total      | 3          |
+| Fruits     | Fruits     |
+| Apple      | 1          |
+| Vegetables | Vegetables |
+| Carrot     | 2          |
+			`,
+		},
+		{
+			desc:  "section rows as separator",
+			input: sectionRows,
+			options: []option{
+				WithSpanCellBehavior(SpanBehaviorMirror),
+				WithSectionRowBehavior(SectionRowBehaviorSeparator),
+			},
+			expected: `
+## Prices
+
+| Name           | Price |
+|----------------|-------|
+| Total          | 3     |
+| **Fruits**     |       |
+| Apple          | 1     |
+| **Vegetables** |       |
+| Carrot         | 2     |
+			`,
+		},
+		{
+			desc:  "split at section rows",
+			input: sectionRows,
+			options: []option{
+				WithSectionRowBehavior(SectionRowBehaviorSplit),
+			},
+			expected: `
+## Prices
+
+| Name  | Price |
+|-------|-------|
+| Total | 3     |
+
+### Fruits
+
+| Name  | Price |
+|-------|-------|
+| Apple | 1     |
+
+### Vegetables
+
+| Name   | Price |
+|--------|-------|
+| Carrot | 2     |
+			`,
+		},
+		{
+			desc: "split with caption and merged header",
+			input: `
+<table>
+	<caption>Prices</caption>
+	<thead>
+		<tr>
+			<th rowspan="2">Name</th>
+			<th colspan="2">Price</th>
+		</tr>
+		<tr>
+			<th>Small</th>
+			<th>Large</th>
+		</tr>
+	</thead>
+	<tbody>
+		<tr>
+			<th colspan="3">Drinks</th>
+		</tr>
+		<tr>
+			<td>Tea</td>
+			<td>1</td>
+			<td>2</td>
+		</tr>
+	</tbody>
+</table>
+			`,
+			options: []option{
+				WithHeaderRowMerging(true),
+				WithSectionRowBehavior(SectionRowBehaviorSplit),
+				WithCaptionPosition(CaptionPositionAbove),
+			},
+			expected: `
+Prices
+
+## Drinks
+
+| Name | Price / Small | Price / Large |
+|------|---------------|---------------|
+| Tea  | 1             | 2             |
+			`,
+		},
+		{
+			desc: "no section rows to split at",
+			input: `
+<table>
+	<tr>
+		<th scope="row" colspan="2">Total</th>
+		<td>3</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithSectionRowBehavior(SectionRowBehaviorSplit),
+			},
+			expected: `
+| Total |   | 3 |
+|-------|---|---|
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestOptionFunc_Alignment(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		options  []option
+		expected string
+	}{
+		{
+			desc: "inline style and classes",
+			input: `
+<table>
+	<tr>
+		<th style="color: red; text-align: center">A</th>
+		<th class="cell text-right">B</th>
+		<th class="has-text-left">C</th>
+		<th align="right" style="text-align: left">D</th>
+	</tr>
+	<tr>
+		<td>1</td>
+		<td>2</td>
+		<td>3</td>
+		<td>4</td>
+	</tr>
+</table>
+			`,
+			options: []option{},
+			expected: `
+| A | B | C | D |
+|:-:|--:|:--|:--|
+| 1 | 2 | 3 | 4 |
+			`,
+		},
+		{
+			desc: "alignment of the cells below the header",
+			input: `
+<table>
+	<tr>
+		<th>Name</th>
+		<th>Price</th>
+	</tr>
+	<tr>
+		<td>Apple</td>
+		<td class="text-end">1</td>
+	</tr>
+</table>
+			`,
+			options: []option{},
+			expected: `
+| Name  | Price |
+|-------|------:|
+| Apple | 1     |
+			`,
+		},
+		{
+			desc: "custom class patterns",
+			input: `
+<table>
+	<tr>
+		<th class="text-right">A</th>
+		<th class="num">B</th>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithAlignmentClassPatterns("", "", `^num$`),
+			},
+			expected: `
+| A | B |
+|---|--:|
+			`,
+		},
+		{
+			desc: "col and colgroup",
+			input: `
+<table>
+	<colgroup>
+		<col>
+		<col span="2" style="text-align: right">
+	</colgroup>
+	<colgroup align="center"></colgroup>
+	<tr>
+		<th>A</th>
+		<th>B</th>
+		<th align="left">C</th>
+		<th>D</th>
+	</tr>
+</table>
+			`,
+			options: []option{},
+			expected: `
+| A | B | C | D |
+|---|--:|:--|:-:|
+			`,
+		},
+		{
+			desc: "col with a huge span",
+			input: `
+<table>
+	<colgroup span="5" align="center"></colgroup>
+	<col span="50000000" align="right">
+	<tr>
+		<td>A</td>
+		<td>B</td>
+	</tr>
+</table>
+			`,
+			options: []option{},
+			expected: `
+|   |   |
+|:-:|:-:|
+| A | B |
+			`,
+		},
+		{
+			desc: "numeric columns",
+			input: `
+<table>
+	<tr>
+		<th>Name</th>
+		<th>Amount</th>
+		<th>Change</th>
+		<th>Code</th>
+	</tr>
+	<tr>
+		<td>Apple</td>
+		<td>1,200</td>
+		<td>+5 %</td>
+		<td>A1</td>
+	</tr>
+	<tr>
+		<td>Banana</td>
+		<td>30.5</td>
+		<td>-2 %</td>
+		<td>7</td>
+	</tr>
+	<tr>
+		<td>Cherry</td>
+		<td></td>
+		<td>n/a</td>
+		<td>B2</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithNumericAlignment(true),
+			},
+			expected: `
+| Name   | Amount | Change | Code |
+|--------|-------:|--------|------|
+| Apple  | 1,200  | +5 %   | A1   |
+| Banana | 30.5   | -2 %   | 7    |
+| Cherry |        | n/a    | B2   |
+			`,
+		},
+		{
+			desc: "numeric columns keep the explicit alignment",
+			input: `
+<table>
+	<tr>
+		<th align="center">Amount</th>
+	</tr>
+	<tr>
+		<td>1</td>
+	</tr>
+</table>
+			`,
+			options: []option{
+				WithNumericAlignment(true),
+			},
+			expected: `
+| Amount |
+|:------:|
+| 1      |
+			`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTablePlugin(tC.options...),
+				),
+			)
+
+			output, err := conv.ConvertString(tC.input)
+			if err != nil {
+				t.Error(err)
+			}
+
+			actual := strings.TrimSpace(output)
+			expected := strings.TrimSpace(tC.expected)
+
+			if actual != expected {
+				t.Errorf("expected\n%s\nbut got\n%s\n", expected, actual)
+			}
+		})
+	}
+}
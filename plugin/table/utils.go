@@ -1,11 +1,14 @@
 package table
 
 import (
+	"bytes"
 	"slices"
 	"strconv"
 	"unicode/utf8"
 
 	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/marker"
+	"github.com/rivo/uniseg"
 	"golang.org/x/net/html"
 )
 
@@ -13,12 +16,57 @@ import (
 // This also ensures that the table is correctly *recognized* as a markdown table.
 const defaultCellWidth = 1
 
+// stringWidth returns the number of columns that the text occupies in a monospace font.
+//
+// The width is calculated per grapheme cluster, so that e.g. East Asian wide
+// characters (e.g. "漢") take up two columns, combining accents (e.g. "e\u0301")
+// take up none and emoji sequences joined by a zero width joiner count as one emoji.
+func stringWidth(b []byte) int {
+	count := 0
+	state := -1
+	for len(b) > 0 {
+		var cluster []byte
+		var boundaries int
+		cluster, b, boundaries, state = uniseg.Step(b, state)
+
+		count += clusterWidth(cluster, boundaries)
+	}
+	return count
+}
+
+// clusterWidth returns the width of a grapheme cluster returned by uniseg.Step.
+func clusterWidth(cluster []byte, boundaries int) int {
+	if isMarker(cluster) {
+		// The markers are removed before the output
+		// so they should not take up any space.
+		return 0
+	}
+	return boundaries >> uniseg.ShiftWidth
+}
+
+func isMarker(cluster []byte) bool {
+	r, size := utf8.DecodeRune(cluster)
+	if size != len(cluster) {
+		return false
+	}
+	return r == marker.MarkerEscaping || r == marker.MarkerCodeBlockNewline
+}
+
+// cellWidth returns the width of the longest line in the cell.
+func cellWidth(cell []byte) int {
+	maxWidth := 0
+	for line := range bytes.SplitSeq(cell, []byte("\n")) {
+		maxWidth = max(maxWidth, stringWidth(line))
+	}
+	return maxWidth
+}
+
 func calculateMaxCounts(rows [][][]byte) []int {
 	maxCounts := make([]int, 0)
 
 	for _, cells := range rows {
 		for index, cell := range cells {
-			count := utf8.RuneCount(cell)
+			count := cellWidth(cell)
 
 			if index >= len(maxCounts) {
 				maxCounts = append(maxCounts, defaultCellWidth)
@@ -266,3 +266,39 @@ func TestApplyModifications(t *testing.T) {
 		})
 	}
 }
+
+func TestCellWidth(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int
+	}{
+		{input: "", expected: 0},
+		{input: "abc", expected: 3},
+		{input: "Müller", expected: 6},
+		{input: "Müller", expected: 6},
+		{input: "日本語", expected: 6},
+		{input: "ｆｕｌｌ", expected: 8},
+		{input: "ｶﾀｶﾅ", expected: 4},
+		{input: "a\nlonger line\nb", expected: 11},
+		{input: "a\n日本語", expected: 6},
+
+		{input: "👍", expected: 2},
+		{input: "👍🏽", expected: 2},
+		{input: "👨\u200d👩\u200d👧", expected: 2},
+		{input: "🇩🇪", expected: 2},
+		{input: "❤️", expected: 2},
+		{input: "soft\u00adhyphen", expected: 10},
+
+		{input: "a\a|b", expected: 3},
+		{input: "\\|", expected: 2},
+		{input: "a\uF002b", expected: 2},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			output := cellWidth([]byte(tC.input))
+			if output != tC.expected {
+				t.Errorf("expected %d but got %d", tC.expected, output)
+			}
+		})
+	}
+}
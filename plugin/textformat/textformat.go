@@ -0,0 +1,336 @@
+package textformat
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"unicode"
+
+	"github.com/JohannesKaufmann/dom"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/domutils"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/escape"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/textutils"
+	"golang.org/x/net/html"
+)
+
+type option func(p *textFormatPlugin) error
+
+type Syntax string
+
+const (
+	// SyntaxDelimiter surrounds the text with delimiters, like the
+	// Pandoc syntax `^sup^` and `~sub~` or `==mark==` and `++ins++`.
+	SyntaxDelimiter Syntax = "delimiter"
+	// SyntaxHTML keeps the element as html, e.g. `<sup>2</sup>`
+	SyntaxHTML Syntax = "html"
+	// SyntaxUnicode uses the unicode characters for superscript
+	// and subscript (e.g. "H₂O"). If the text contains characters
+	// without a unicode equivalent, the html is used instead.
+	SyntaxUnicode Syntax = "unicode"
+	// SyntaxText only keeps the text (that is the behavior without this plugin).
+	SyntaxText Syntax = "text"
+)
+
+func validateSyntax(element string, syntax Syntax, allowUnicode bool) error {
+	switch syntax {
+	case SyntaxDelimiter, SyntaxHTML, SyntaxText:
+		return nil
+	case SyntaxUnicode:
+		if allowUnicode {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown value %q for %s syntax", syntax, element)
+}
+
+// WithSuperscriptSyntax configures how <sup> is rendered (default "delimiter").
+//
+//	"delimiter", "html", "unicode" or "text"
+func WithSuperscriptSyntax(syntax Syntax) option {
+	return func(p *textFormatPlugin) error {
+		if err := validateSyntax("superscript", syntax, true); err != nil {
+			return err
+		}
+		p.syntaxes["sup"] = syntax
+		return nil
+	}
+}
+
+// WithSubscriptSyntax configures how <sub> is rendered (default "delimiter").
+//
+//	"delimiter", "html", "unicode" or "text"
+func WithSubscriptSyntax(syntax Syntax) option {
+	return func(p *textFormatPlugin) error {
+		if err := validateSyntax("subscript", syntax, true); err != nil {
+			return err
+		}
+		p.syntaxes["sub"] = syntax
+		return nil
+	}
+}
+
+// WithHighlightSyntax configures how <mark> is rendered (default "delimiter").
+//
+//	"delimiter", "html" or "text"
+func WithHighlightSyntax(syntax Syntax) option {
+	return func(p *textFormatPlugin) error {
+		if err := validateSyntax("highlight", syntax, false); err != nil {
+			return err
+		}
+		p.syntaxes["mark"] = syntax
+		return nil
+	}
+}
+
+// WithInsertSyntax configures how <ins> and <u> are rendered (default "delimiter").
+//
+//	"delimiter", "html" or "text"
+func WithInsertSyntax(syntax Syntax) option {
+	return func(p *textFormatPlugin) error {
+		if err := validateSyntax("insert", syntax, false); err != nil {
+			return err
+		}
+		p.syntaxes["ins"] = syntax
+		p.syntaxes["u"] = syntax
+		return nil
+	}
+}
+
+type textFormatPlugin struct {
+	m   sync.RWMutex
+	err error
+
+	// syntaxes maps the tag name to the configured syntax
+	syntaxes map[string]Syntax
+}
+
+func (p *textFormatPlugin) setError(err error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.err = err
+}
+func (p *textFormatPlugin) getError() error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	return p.err
+}
+
+// delimiters are used for the `SyntaxDelimiter`
+var delimiters = map[string]string{
+	"sup":  "^",
+	"sub":  "~",
+	"mark": "==",
+	"ins":  "++",
+	"u":    "++",
+}
+
+// NewTextFormatPlugin converts `<sup>`, `<sub>`, `<mark>`, `<ins>` and `<u>`.
+//
+// By default the delimiters `^sup^`, `~sub~`, `==mark==` and `++ins++` are used,
+// which are supported by Pandoc and many markdown-it plugins.
+func NewTextFormatPlugin(opts ...option) converter.Plugin {
+	plugin := &textFormatPlugin{
+		syntaxes: map[string]Syntax{
+			"sup":  SyntaxDelimiter,
+			"sub":  SyntaxDelimiter,
+			"mark": SyntaxDelimiter,
+			"ins":  SyntaxDelimiter,
+			"u":    SyntaxDelimiter,
+		},
+	}
+	for _, opt := range opts {
+		err := opt(plugin)
+		if err != nil {
+			plugin.setError(err)
+			break
+		}
+	}
+	return plugin
+}
+
+func (p *textFormatPlugin) Name() string {
+	return "textformat"
+}
+
+func (p *textFormatPlugin) Init(conv *converter.Converter) error {
+	if err := p.getError(); err != nil {
+		// Any error raised from the option func
+		return err
+	}
+
+	conv.Register.PreRenderer(p.handlePreRender, converter.PriorityStandard)
+
+	conv.Register.EscapedChar('^', '~', '=', '+')
+	conv.Register.UnEscaper(p.handleUnEscapers, converter.PriorityStandard)
+
+	conv.Register.Renderer(p.handleRender, converter.PriorityStandard)
+
+	return nil
+}
+
+func (p *textFormatPlugin) handlePreRender(ctx converter.Context, doc *html.Node) {
+	for name := range delimiters {
+		isName := func(n *html.Node) bool {
+			return dom.NodeName(n) == name
+		}
+		domutils.RemoveRedundant(doc, func(a, b *html.Node) bool {
+			return isName(a) && isName(b)
+		})
+		domutils.MergeAdjacent(doc, isName)
+	}
+}
+
+func (p *textFormatPlugin) handleUnEscapers(chars []byte, index int) int {
+	switch chars[index] {
+	case '^', '~':
+		if p.syntaxes["sup"] != SyntaxDelimiter && chars[index] == '^' {
+			return -1
+		}
+		if p.syntaxes["sub"] != SyntaxDelimiter && chars[index] == '~' {
+			return -1
+		}
+
+		next := escape.GetNextAsRune(chars, index)
+		if unicode.IsSpace(next) || next == 0 {
+			// The content can not start with whitespace
+			return -1
+		}
+		return 1
+
+	case '=', '+':
+		if chars[index] == '=' && p.syntaxes["mark"] != SyntaxDelimiter {
+			return -1
+		}
+		if chars[index] == '+' && p.syntaxes["ins"] != SyntaxDelimiter {
+			return -1
+		}
+
+		// Only a double "==" or "++" is a delimiter. Escaping the first
+		// character is enough to break it up.
+		if escape.GetNextAsRune(chars, index) != rune(chars[index]) {
+			return -1
+		}
+		if escape.GetPrevAsRune(chars, index) == rune(chars[index]) {
+			// Already handled by the previous character
+			return -1
+		}
+
+		// The rune after the second character of the delimiter
+		second := index + 1
+		for second < len(chars) && chars[second] != chars[index] {
+			// Skip the placeholder
+			second++
+		}
+		after := escape.GetNextAsRune(chars, second)
+
+		// Similar to the other delimiters: If every opening delimiter
+		// is escaped, the closing delimiters (e.g. "C++") are harmless.
+		if unicode.IsSpace(after) || after == 0 {
+			return -1
+		}
+		return 1
+	}
+
+	return -1
+}
+
+func (p *textFormatPlugin) handleRender(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	name := dom.NodeName(n)
+
+	delimiter, ok := delimiters[name]
+	if !ok {
+		return converter.RenderTryNext
+	}
+
+	switch p.syntaxes[name] {
+	case SyntaxDelimiter:
+		return p.renderDelimiter(ctx, w, n, delimiter)
+	case SyntaxHTML:
+		return p.renderHTML(ctx, w, n)
+	case SyntaxUnicode:
+		return p.renderUnicode(ctx, w, n)
+	default:
+		ctx.RenderChildNodes(ctx, w, n)
+		return converter.RenderSuccess
+	}
+}
+
+func (p *textFormatPlugin) renderDelimiter(ctx converter.Context, w converter.Writer, n *html.Node, delimiter string) converter.RenderStatus {
+	var buf bytes.Buffer
+	ctx.RenderChildNodes(ctx, &buf, n)
+
+	content := buf.Bytes()
+
+	if delimiter == "^" || delimiter == "~" {
+		// For Pandoc the spaces (and newlines) inside of a
+		// superscript or subscript need to be escaped.
+		leftExtra, trimmed, rightExtra := textutils.SurroundingSpaces(content)
+		if trimmed == nil {
+			w.Write(leftExtra)
+			return converter.RenderSuccess
+		}
+
+		w.Write(leftExtra)
+		w.WriteString(delimiter)
+		w.Write(escapeSpaces(trimmed))
+		w.WriteString(delimiter)
+		w.Write(rightExtra)
+		return converter.RenderSuccess
+	}
+
+	// If there is a newline character between the start and end delimiter
+	// the delimiters won't be recognized. So on _every_ line we put start & end delimiters.
+	content = textutils.DelimiterForEveryLine(content, []byte(delimiter))
+
+	w.Write(content)
+	return converter.RenderSuccess
+}
+
+func escapeSpaces(content []byte) []byte {
+	var buf bytes.Buffer
+	lastWasSpace := false
+	for _, r := range string(content) {
+		if unicode.IsSpace(r) {
+			if !lastWasSpace {
+				buf.WriteString(`\ `)
+			}
+			lastWasSpace = true
+			continue
+		}
+
+		lastWasSpace = false
+		buf.WriteRune(r)
+	}
+	return buf.Bytes()
+}
+
+func (p *textFormatPlugin) renderHTML(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	name := dom.NodeName(n)
+
+	// Inside of inline html the content is still markdown.
+	w.WriteString("<" + name + ">")
+	ctx.RenderChildNodes(ctx, w, n)
+	w.WriteString("</" + name + ">")
+
+	return converter.RenderSuccess
+}
+
+func (p *textFormatPlugin) renderUnicode(ctx converter.Context, w converter.Writer, n *html.Node) converter.RenderStatus {
+	for _, child := range dom.AllChildNodes(n) {
+		if child.Type != html.TextNode {
+			// e.g. a footnote link `<sup><a href="#fn1">1</a></sup>`
+			return p.renderHTML(ctx, w, n)
+		}
+	}
+
+	text, ok := toUnicode(dom.NodeName(n), dom.CollectText(n))
+	if !ok {
+		return p.renderHTML(ctx, w, n)
+	}
+
+	w.WriteString(text)
+	return converter.RenderSuccess
+}
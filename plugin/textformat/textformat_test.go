@@ -0,0 +1,187 @@
+package textformat
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/internal/tester"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+func TestNewTextFormatPlugin(t *testing.T) {
+	runs := []struct {
+		desc     string
+		options  []option
+		input    string
+		expected string
+	}{
+		// - - - - - - - - - - delimiter - - - - - - - - - - //
+		{
+			desc:     "subscript",
+			input:    `<p>H<sub>2</sub>O</p>`,
+			expected: `H~2~O`,
+		},
+		{
+			desc:     "superscript",
+			input:    `<p>E = mc<sup>2</sup></p>`,
+			expected: `E = mc^2^`,
+		},
+		{
+			desc:     "superscript with spaces",
+			input:    `<p>A<sup> the  note </sup>B</p>`,
+			expected: `A ^the\ note^ B`,
+		},
+		{
+			desc:     "superscript with link",
+			input:    `<p>Text<sup><a href="#fn1">1</a></sup></p>`,
+			expected: `Text^[1](#fn1)^`,
+		},
+		{
+			desc:     "empty superscript",
+			input:    `<p>A <sup></sup>B</p>`,
+			expected: `A B`,
+		},
+		{
+			desc:     "adjacent superscripts",
+			input:    `<p>x<sup>1</sup><sup>2</sup></p>`,
+			expected: `x^12^`,
+		},
+		{
+			desc:     "highlight",
+			input:    `<p>Some <mark>important <b>bold</b></mark> text</p>`,
+			expected: `Some ==important **bold**== text`,
+		},
+		{
+			desc:     "highlight on multiple lines",
+			input:    `<p><mark>A<br />B</mark></p>`,
+			expected: "==A==  \n==B==",
+		},
+		{
+			desc:     "insert and underline",
+			input:    `<p><ins>inserted</ins> and <u>underlined</u></p>`,
+			expected: `++inserted++ and ++underlined++`,
+		},
+
+		// - - - - - - - - - - escaping - - - - - - - - - - //
+		{
+			desc:     "escape caret and tilde",
+			input:    `<p>2^10 and ^ alone and ~approx</p>`,
+			expected: `2\^10 and ^ alone and \~approx`,
+		},
+		{
+			desc:     "escape double equals",
+			input:    `<p>a == b and a==b and ==c==</p>`,
+			expected: `a == b and a\==b and \==c==`,
+		},
+		{
+			desc:     "escape double plus",
+			input:    `<p>C++ and ++i and +1</p>`,
+			expected: `C++ and \++i and +1`,
+		},
+		{
+			desc: "no escaping for the html syntax",
+			options: []option{
+				WithSuperscriptSyntax(SyntaxHTML),
+				WithHighlightSyntax(SyntaxHTML),
+			},
+			input:    `<p>2^10 and ==c==</p>`,
+			expected: `2^10 and ==c==`,
+		},
+
+		// - - - - - - - - - - other syntaxes - - - - - - - - - - //
+		{
+			desc: "html",
+			options: []option{
+				WithSuperscriptSyntax(SyntaxHTML),
+				WithSubscriptSyntax(SyntaxHTML),
+				WithHighlightSyntax(SyntaxHTML),
+				WithInsertSyntax(SyntaxHTML),
+			},
+			input:    `<p>H<sub>2</sub>O, x<sup class="a">*2*</sup>, <mark>mark</mark>, <u>u</u></p>`,
+			expected: `H<sub>2</sub>O, x<sup>\*2\*</sup>, <mark>mark</mark>, <u>u</u>`,
+		},
+		{
+			desc: "unicode",
+			options: []option{
+				WithSuperscriptSyntax(SyntaxUnicode),
+				WithSubscriptSyntax(SyntaxUnicode),
+			},
+			input:    `<p>H<sub>2</sub>O and x<sup>n+1</sup> and CO<sub>2</sub></p>`,
+			expected: `H₂O and xⁿ⁺¹ and CO₂`,
+		},
+		{
+			desc: "unicode falls back to html",
+			options: []option{
+				WithSuperscriptSyntax(SyntaxUnicode),
+			},
+			input:    `<p>1<sup>st</sup> and Text<sup><a href="#fn1">1</a></sup></p>`,
+			expected: `1<sup>st</sup> and Text<sup>[1](#fn1)</sup>`,
+		},
+		{
+			desc: "text",
+			options: []option{
+				WithHighlightSyntax(SyntaxText),
+			},
+			input:    `<p>Some <mark>marked</mark> text</p>`,
+			expected: `Some marked text`,
+		},
+	}
+	for _, run := range runs {
+		t.Run(run.desc, func(t *testing.T) {
+			conv := converter.NewConverter(
+				converter.WithPlugins(
+					base.NewBasePlugin(),
+					commonmark.NewCommonmarkPlugin(),
+					NewTextFormatPlugin(run.options...),
+				),
+			)
+
+			out, err := conv.ConvertString(run.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != run.expected {
+				t.Errorf("expected %q but got %q", run.expected, out)
+			}
+		})
+	}
+}
+
+func TestNewTextFormatPlugin_InvalidSyntax(t *testing.T) {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+			NewTextFormatPlugin(
+				WithHighlightSyntax(SyntaxUnicode),
+			),
+		),
+	)
+
+	_, err := conv.ConvertString("<p>text</p>")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := `error while initializing "textformat" plugin: unknown value "unicode" for highlight syntax`
+	if err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
+
+func TestGoldenFiles(t *testing.T) {
+	goldenFileConvert := func(htmlInput []byte) ([]byte, error) {
+		conv := converter.NewConverter(
+			converter.WithPlugins(
+				base.NewBasePlugin(),
+				commonmark.NewCommonmarkPlugin(),
+				NewTextFormatPlugin(),
+			),
+		)
+
+		return conv.ConvertReader(bytes.NewReader(htmlInput))
+	}
+
+	tester.GoldenFiles(t, goldenFileConvert, goldenFileConvert)
+}
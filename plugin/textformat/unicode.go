@@ -0,0 +1,42 @@
+package textformat
+
+import "strings"
+
+var superscripts = map[rune]rune{
+	'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴',
+	'5': '⁵', '6': '⁶', '7': '⁷', '8': '⁸', '9': '⁹',
+	'+': '⁺', '-': '⁻', '−': '⁻', '=': '⁼', '(': '⁽', ')': '⁾',
+	'i': 'ⁱ', 'n': 'ⁿ',
+}
+
+var subscripts = map[rune]rune{
+	'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄',
+	'5': '₅', '6': '₆', '7': '₇', '8': '₈', '9': '₉',
+	'+': '₊', '-': '₋', '−': '₋', '=': '₌', '(': '₍', ')': '₎',
+	'a': 'ₐ', 'e': 'ₑ', 'o': 'ₒ', 'x': 'ₓ', 'h': 'ₕ', 'k': 'ₖ',
+	'l': 'ₗ', 'm': 'ₘ', 'n': 'ₙ', 'p': 'ₚ', 's': 'ₛ', 't': 'ₜ',
+}
+
+// toUnicode converts the text to the unicode superscript (or subscript)
+// characters. It returns false if there is a character without an equivalent.
+func toUnicode(tagName string, text string) (string, bool) {
+	table := superscripts
+	if tagName == "sub" {
+		table = subscripts
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		converted, ok := table[r]
+		if !ok {
+			return "", false
+		}
+		b.WriteRune(converted)
+	}
+	return b.String(), true
+}